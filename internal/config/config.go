@@ -1,26 +1,396 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"os"
+	"unicode/utf8"
 
 	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 )
 
-func LoadEnv() error {
-	err := godotenv.Load(".env")
-	if err != nil {
-		log.Println(fmt.Printf("Erro ao carregar o arquivo .env:%v", err))
-		return err
+// Config is the typed application configuration. It is loaded once at startup by Load
+// and the relevant sections are injected into providers and services.
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Mongo     MongoConfig     `yaml:"mongo"`
+	QueryAI   QueryAIConfig   `yaml:"query_ai"`
+	Meta      MetaConfig      `yaml:"meta"`
+	Infobip   InfobipConfig   `yaml:"infobip"`
+	Telegram  TelegramConfig  `yaml:"telegram"`
+	WebChat   WebChatConfig   `yaml:"webchat"`
+	Queue     QueueConfig     `yaml:"queue"`
+	Messages  MessagesConfig  `yaml:"messages"`
+	Templates TemplatesConfig `yaml:"templates"`
+	Media     MediaConfig     `yaml:"media"`
+}
+
+type ServerConfig struct {
+	Port        string `yaml:"port" env:"PORT"`
+	LogLevel    string `yaml:"log_level" env:"LOG_LEVEL"`
+	Env         string `yaml:"env" env:"ENV"`
+	AdminAPIKey string `yaml:"admin_api_key" env:"ADMIN_API_KEY"`
+}
+
+type MongoConfig struct {
+	URI      string `yaml:"uri" env:"MONGODB_URI"`
+	Database string `yaml:"database" env:"MONGODB_DATABASE"`
+}
+
+type QueryAIConfig struct {
+	Host string `yaml:"host" env:"QUERY_AI_API_HOST"`
+}
+
+type MetaConfig struct {
+	GraphAPIURL     string `yaml:"graph_api_url" env:"GRAPH_API_URL"`
+	GraphAPIVersion string `yaml:"graph_api_version" env:"GRAPH_API_VERSION"`
+	AccessToken     string `yaml:"access_token" env:"WHATSAPP_ACCESS_TOKEN"`
+	PhoneNumberID   string `yaml:"phone_number_id" env:"WHATSAPP_PHONE_NUMBER_ID"`
+	AppSecret       string `yaml:"app_secret" env:"META_APP_SECRET"`
+	VerifyToken     string `yaml:"verify_token" env:"API_KEY"`
+	UploadMedia     bool   `yaml:"upload_media" env:"META_UPLOAD_MEDIA"`
+	MediaIDTTLHours int    `yaml:"media_id_ttl_hours" env:"META_MEDIA_ID_TTL_HOURS"`
+
+	MessengerAccessToken string `yaml:"messenger_access_token" env:"META_MESSENGER_ACCESS_TOKEN"`
+	InstagramAccessToken string `yaml:"instagram_access_token" env:"META_INSTAGRAM_ACCESS_TOKEN"`
+}
+
+type InfobipConfig struct {
+	URL                       string               `yaml:"url" env:"INFOBIP_URL"`
+	ClientID                  string               `yaml:"client_id" env:"INFOBIP_CLIENT_ID"`
+	ClientSecret              string               `yaml:"client_secret" env:"INFOBIP_CLIENT_SECRET"`
+	PhoneNumber               string               `yaml:"phone_number" env:"WHATSAPP_PHONE_NUMBER"`
+	TokenRefreshMarginSeconds int                  `yaml:"token_refresh_margin_seconds" env:"INFOBIP_TOKEN_REFRESH_MARGIN_SECONDS"`
+	NotifyURL                 string               `yaml:"notify_url" env:"INFOBIP_NOTIFY_URL"`
+	Webhook                   InfobipWebhookConfig `yaml:"webhook"`
+}
+
+type InfobipWebhookConfig struct {
+	BasicUsername     string   `yaml:"basic_username" env:"INFOBIP_WEBHOOK_BASIC_USER"`
+	BasicPassword     string   `yaml:"basic_password" env:"INFOBIP_WEBHOOK_BASIC_PASSWORD"`
+	HeaderName        string   `yaml:"header_name" env:"INFOBIP_WEBHOOK_HEADER_NAME"`
+	HeaderSecret      string   `yaml:"header_secret" env:"INFOBIP_WEBHOOK_SECRET"`
+	QueryParam        string   `yaml:"query_param" env:"INFOBIP_WEBHOOK_QUERY_PARAM"`
+	QueryToken        string   `yaml:"query_token" env:"INFOBIP_WEBHOOK_QUERY_TOKEN"`
+	AllowedCIDRs      []string `yaml:"allowed_ips" env:"INFOBIP_WEBHOOK_ALLOWED_IPS"`
+	TrustForwardedFor bool     `yaml:"trust_proxy" env:"INFOBIP_WEBHOOK_TRUST_PROXY"`
+}
+
+type TelegramConfig struct {
+	APIURL             string `yaml:"api_url" env:"TELEGRAM_API_URL"`
+	BotToken           string `yaml:"bot_token" env:"TELEGRAM_BOT_TOKEN"`
+	Mode               string `yaml:"mode" env:"TELEGRAM_MODE"`
+	WebhookURL         string `yaml:"webhook_url" env:"TELEGRAM_WEBHOOK_URL"`
+	WebhookSecret      string `yaml:"webhook_secret" env:"TELEGRAM_WEBHOOK_SECRET"`
+	PollTimeoutSeconds int    `yaml:"poll_timeout_seconds" env:"TELEGRAM_POLL_TIMEOUT_SECONDS"`
+}
+
+type WebChatConfig struct {
+	Enabled          bool     `yaml:"enabled" env:"WEBCHAT_ENABLED"`
+	SigningSecret    string   `yaml:"signing_secret" env:"WEBCHAT_SIGNING_SECRET"`
+	SessionTTLHours  int      `yaml:"session_ttl_hours" env:"WEBCHAT_SESSION_TTL_HOURS"`
+	AllowedOrigins   []string `yaml:"allowed_origins" env:"WEBCHAT_ALLOWED_ORIGINS"`
+	MaxMessageLength int      `yaml:"max_message_length" env:"WEBCHAT_MAX_MESSAGE_LENGTH"`
+	TrustProxy       bool     `yaml:"trust_proxy" env:"WEBCHAT_TRUST_PROXY"`
+
+	SessionMessagesPerMinute int `yaml:"session_messages_per_minute" env:"WEBCHAT_SESSION_MESSAGES_PER_MINUTE"`
+	IPMessagesPerMinute      int `yaml:"ip_messages_per_minute" env:"WEBCHAT_IP_MESSAGES_PER_MINUTE"`
+	IPSessionsPerHour        int `yaml:"ip_sessions_per_hour" env:"WEBCHAT_IP_SESSIONS_PER_HOUR"`
+}
+
+type MessagesConfig struct {
+	UnsupportedReply  string `yaml:"unsupported_reply" env:"UNSUPPORTED_MESSAGE_REPLY"`
+	OptionsButtonText string `yaml:"options_button_text" env:"OPTIONS_BUTTON_TEXT"`
+}
+
+type TemplatesConfig struct {
+	File string `yaml:"file" env:"TEMPLATES_FILE"`
+}
+
+type MediaConfig struct {
+	Store         string   `yaml:"store" env:"MEDIA_STORE"`
+	LocalPath     string   `yaml:"local_path" env:"MEDIA_LOCAL_PATH"`
+	MaxSizeMB     int      `yaml:"max_size_mb" env:"MEDIA_MAX_SIZE_MB"`
+	PublicBaseURL string   `yaml:"public_base_url" env:"MEDIA_PUBLIC_BASE_URL"`
+	SigningSecret string   `yaml:"signing_secret" env:"MEDIA_SIGNING_SECRET"`
+	URLTTLMinutes int      `yaml:"url_ttl_minutes" env:"MEDIA_URL_TTL_MINUTES"`
+	RehostAIAudio bool     `yaml:"rehost_ai_audio" env:"MEDIA_REHOST_AI_AUDIO"`
+	S3            S3Config `yaml:"s3"`
+}
+
+type S3Config struct {
+	Endpoint        string `yaml:"endpoint" env:"MEDIA_S3_ENDPOINT"`
+	Region          string `yaml:"region" env:"MEDIA_S3_REGION"`
+	Bucket          string `yaml:"bucket" env:"MEDIA_S3_BUCKET"`
+	AccessKeyID     string `yaml:"access_key_id" env:"MEDIA_S3_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secret_access_key" env:"MEDIA_S3_SECRET_ACCESS_KEY"`
+	UsePathStyle    bool   `yaml:"use_path_style" env:"MEDIA_S3_USE_PATH_STYLE"`
+}
+
+type QueueConfig struct {
+	Workers       int `yaml:"workers" env:"INBOUND_QUEUE_WORKERS"`
+	MaxAttempts   int `yaml:"max_attempts" env:"INBOUND_QUEUE_MAX_ATTEMPTS"`
+	LeaseSeconds  int `yaml:"lease_seconds" env:"INBOUND_QUEUE_LEASE_SECONDS"`
+	DedupTTLHours int `yaml:"dedup_ttl_hours" env:"INBOUND_DEDUP_TTL_HOURS"`
+}
+
+// Default returns the configuration used for every value not set in the YAML file or the environment.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:     "8001",
+			LogLevel: "info",
+			Env:      "development",
+		},
+		Mongo: MongoConfig{
+			Database: "UserContext",
+		},
+		Meta: MetaConfig{
+			GraphAPIURL:     "https://graph.facebook.com",
+			GraphAPIVersion: "v21.0",
+			UploadMedia:     true,
+			MediaIDTTLHours: 24 * 29,
+		},
+		Infobip: InfobipConfig{
+			TokenRefreshMarginSeconds: 60,
+			Webhook: InfobipWebhookConfig{
+				HeaderName: "X-Webhook-Secret",
+				QueryParam: "token",
+			},
+		},
+		Telegram: TelegramConfig{
+			APIURL:             "https://api.telegram.org",
+			Mode:               "webhook",
+			PollTimeoutSeconds: 30,
+		},
+		WebChat: WebChatConfig{
+			SessionTTLHours:          24 * 30,
+			MaxMessageLength:         2000,
+			SessionMessagesPerMinute: 10,
+			IPMessagesPerMinute:      30,
+			IPSessionsPerHour:        10,
+		},
+		Queue: QueueConfig{
+			Workers:       4,
+			MaxAttempts:   5,
+			LeaseSeconds:  120,
+			DedupTTLHours: 72,
+		},
+		Media: MediaConfig{
+			Store:         "local",
+			LocalPath:     "./data/media",
+			MaxSizeMB:     100,
+			PublicBaseURL: "http://localhost:8001",
+			URLTTLMinutes: 60,
+			S3: S3Config{
+				Region: "us-east-1",
+			},
+		},
+		Messages: MessagesConfig{
+			UnsupportedReply:  "Desculpe, ainda não consigo entender esse tipo de mensagem. Pode me escrever em texto? 😊",
+			OptionsButtonText: "Ver opções",
+		},
 	}
-	return nil
 }
 
-func GetEnv(key string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		log.Fatalf("Environment variable %s is required but not set", key)
+// Load builds the configuration from, in increasing order of precedence, the defaults,
+// the optional YAML file named by CONFIG_FILE and the environment (including `.env`).
+//
+// Returns:
+//   - *Config: The loaded configuration.
+//   - error: Returns every problem found while loading or validating, joined into a single error.
+func Load() (*Config, error) {
+	if err := godotenv.Load(".env"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load .env file: %w", err)
 	}
-	return value
+
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+		if err := yaml.Unmarshal(content, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	// Variables that fail to parse keep their previous value, so validation still runs and
+	// every problem is reported in a single pass.
+	if err := errors.Join(applyEnv(&cfg), cfg.Validate()); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// MetaEnabled reports whether the Meta Cloud API provider is configured.
+func (c *Config) MetaEnabled() bool {
+	return c.Meta.AccessToken != "" || c.Meta.PhoneNumberID != ""
+}
+
+// MessengerEnabled reports whether the Facebook Messenger channel is configured.
+func (c *Config) MessengerEnabled() bool {
+	return c.Meta.MessengerAccessToken != ""
+}
+
+// InstagramEnabled reports whether the Instagram Direct channel is configured.
+func (c *Config) InstagramEnabled() bool {
+	return c.Meta.InstagramAccessToken != ""
+}
+
+// InfobipEnabled reports whether the Infobip provider is configured.
+func (c *Config) InfobipEnabled() bool {
+	return c.Infobip.URL != "" || c.Infobip.ClientID != ""
+}
+
+// TelegramEnabled reports whether the Telegram channel is configured.
+func (c *Config) TelegramEnabled() bool {
+	return c.Telegram.BotToken != ""
+}
+
+// Validate checks the whole configuration and reports every problem at once.
+func (c *Config) Validate() error {
+	var errs []error
+	required := func(value string, name string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", name))
+		}
+	}
+
+	required(c.Server.Port, "server.port (PORT)")
+	if _, err := logrus.ParseLevel(c.Server.LogLevel); err != nil {
+		errs = append(errs, fmt.Errorf("server.log_level (LOG_LEVEL) is invalid: %s", c.Server.LogLevel))
+	}
+
+	required(c.Mongo.URI, "mongo.uri (MONGODB_URI)")
+	required(c.Mongo.Database, "mongo.database (MONGODB_DATABASE)")
+	required(c.QueryAI.Host, "query_ai.host (QUERY_AI_API_HOST)")
+
+	if !c.MetaEnabled() && !c.MessengerEnabled() && !c.InstagramEnabled() && !c.InfobipEnabled() && !c.TelegramEnabled() && !c.WebChat.Enabled {
+		errs = append(errs, fmt.Errorf("at least one provider (meta, messenger, instagram, infobip, telegram or webchat) must be configured"))
+	}
+
+	if c.MetaEnabled() {
+		required(c.Meta.GraphAPIURL, "meta.graph_api_url (GRAPH_API_URL)")
+		required(c.Meta.GraphAPIVersion, "meta.graph_api_version (GRAPH_API_VERSION)")
+		required(c.Meta.AccessToken, "meta.access_token (WHATSAPP_ACCESS_TOKEN)")
+		required(c.Meta.PhoneNumberID, "meta.phone_number_id (WHATSAPP_PHONE_NUMBER_ID)")
+		required(c.Meta.AppSecret, "meta.app_secret (META_APP_SECRET)")
+		required(c.Meta.VerifyToken, "meta.verify_token (API_KEY)")
+		if c.Meta.MediaIDTTLHours < 1 || c.Meta.MediaIDTTLHours > 24*30 {
+			errs = append(errs, fmt.Errorf("meta.media_id_ttl_hours (META_MEDIA_ID_TTL_HOURS) must be between 1 and 720, Meta keeps uploaded media for 30 days"))
+		}
+	}
+
+	// Messenger and Instagram share the Meta webhook, its signature and its verify token.
+	if !c.MetaEnabled() && (c.MessengerEnabled() || c.InstagramEnabled()) {
+		required(c.Meta.GraphAPIURL, "meta.graph_api_url (GRAPH_API_URL)")
+		required(c.Meta.GraphAPIVersion, "meta.graph_api_version (GRAPH_API_VERSION)")
+		required(c.Meta.AppSecret, "meta.app_secret (META_APP_SECRET)")
+		required(c.Meta.VerifyToken, "meta.verify_token (API_KEY)")
+	}
+
+	if c.InfobipEnabled() {
+		required(c.Infobip.URL, "infobip.url (INFOBIP_URL)")
+		required(c.Infobip.ClientID, "infobip.client_id (INFOBIP_CLIENT_ID)")
+		required(c.Infobip.ClientSecret, "infobip.client_secret (INFOBIP_CLIENT_SECRET)")
+		required(c.Infobip.PhoneNumber, "infobip.phone_number (WHATSAPP_PHONE_NUMBER)")
+
+		webhook := c.Infobip.Webhook
+		if webhook.BasicUsername == "" && webhook.HeaderSecret == "" && webhook.QueryToken == "" {
+			errs = append(errs, fmt.Errorf("infobip.webhook requires basic auth, a header secret or a query token"))
+		}
+		if webhook.BasicUsername != "" {
+			required(webhook.BasicPassword, "infobip.webhook.basic_password (INFOBIP_WEBHOOK_BASIC_PASSWORD)")
+		}
+		if webhook.HeaderSecret != "" {
+			required(webhook.HeaderName, "infobip.webhook.header_name (INFOBIP_WEBHOOK_HEADER_NAME)")
+		}
+		if webhook.QueryToken != "" {
+			required(webhook.QueryParam, "infobip.webhook.query_param (INFOBIP_WEBHOOK_QUERY_PARAM)")
+		}
+		if c.Infobip.TokenRefreshMarginSeconds < 0 {
+			errs = append(errs, fmt.Errorf("infobip.token_refresh_margin_seconds must not be negative"))
+		}
+	}
+
+	if c.TelegramEnabled() {
+		required(c.Telegram.APIURL, "telegram.api_url (TELEGRAM_API_URL)")
+		switch c.Telegram.Mode {
+		case "webhook":
+			required(c.Telegram.WebhookSecret, "telegram.webhook_secret (TELEGRAM_WEBHOOK_SECRET)")
+		case "polling":
+			if c.Telegram.PollTimeoutSeconds < 1 || c.Telegram.PollTimeoutSeconds > 50 {
+				errs = append(errs, fmt.Errorf("telegram.poll_timeout_seconds (TELEGRAM_POLL_TIMEOUT_SECONDS) must be between 1 and 50"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("telegram.mode (TELEGRAM_MODE) must be webhook or polling, got %q", c.Telegram.Mode))
+		}
+	}
+
+	if c.WebChat.Enabled {
+		required(c.WebChat.SigningSecret, "webchat.signing_secret (WEBCHAT_SIGNING_SECRET)")
+		if len(c.WebChat.AllowedOrigins) == 0 {
+			errs = append(errs, fmt.Errorf("webchat.allowed_origins (WEBCHAT_ALLOWED_ORIGINS) must list the websites embedding the widget"))
+		}
+		if c.WebChat.SessionTTLHours < 1 {
+			errs = append(errs, fmt.Errorf("webchat.session_ttl_hours (WEBCHAT_SESSION_TTL_HOURS) must be at least 1"))
+		}
+		if c.WebChat.MaxMessageLength < 1 {
+			errs = append(errs, fmt.Errorf("webchat.max_message_length (WEBCHAT_MAX_MESSAGE_LENGTH) must be at least 1"))
+		}
+		if c.WebChat.SessionMessagesPerMinute < 1 {
+			errs = append(errs, fmt.Errorf("webchat.session_messages_per_minute (WEBCHAT_SESSION_MESSAGES_PER_MINUTE) must be at least 1"))
+		}
+		if c.WebChat.IPMessagesPerMinute < 1 {
+			errs = append(errs, fmt.Errorf("webchat.ip_messages_per_minute (WEBCHAT_IP_MESSAGES_PER_MINUTE) must be at least 1"))
+		}
+		if c.WebChat.IPSessionsPerHour < 1 {
+			errs = append(errs, fmt.Errorf("webchat.ip_sessions_per_hour (WEBCHAT_IP_SESSIONS_PER_HOUR) must be at least 1"))
+		}
+	}
+
+	if c.Messages.OptionsButtonText == "" || utf8.RuneCountInString(c.Messages.OptionsButtonText) > 20 {
+		errs = append(errs, fmt.Errorf("messages.options_button_text (OPTIONS_BUTTON_TEXT) must have 1 to 20 characters"))
+	}
+
+	required(c.Media.PublicBaseURL, "media.public_base_url (MEDIA_PUBLIC_BASE_URL)")
+	required(c.Media.SigningSecret, "media.signing_secret (MEDIA_SIGNING_SECRET)")
+	if c.Media.URLTTLMinutes < 1 {
+		errs = append(errs, fmt.Errorf("media.url_ttl_minutes (MEDIA_URL_TTL_MINUTES) must be at least 1"))
+	}
+	if c.Media.MaxSizeMB < 1 {
+		errs = append(errs, fmt.Errorf("media.max_size_mb (MEDIA_MAX_SIZE_MB) must be at least 1"))
+	}
+	switch c.Media.Store {
+	case "local":
+		required(c.Media.LocalPath, "media.local_path (MEDIA_LOCAL_PATH)")
+	case "s3":
+		required(c.Media.S3.Endpoint, "media.s3.endpoint (MEDIA_S3_ENDPOINT)")
+		required(c.Media.S3.Region, "media.s3.region (MEDIA_S3_REGION)")
+		required(c.Media.S3.Bucket, "media.s3.bucket (MEDIA_S3_BUCKET)")
+		required(c.Media.S3.AccessKeyID, "media.s3.access_key_id (MEDIA_S3_ACCESS_KEY_ID)")
+		required(c.Media.S3.SecretAccessKey, "media.s3.secret_access_key (MEDIA_S3_SECRET_ACCESS_KEY)")
+	default:
+		errs = append(errs, fmt.Errorf("media.store (MEDIA_STORE) must be local or s3, got %q", c.Media.Store))
+	}
+
+	if c.Queue.Workers < 1 {
+		errs = append(errs, fmt.Errorf("queue.workers (INBOUND_QUEUE_WORKERS) must be at least 1"))
+	}
+	if c.Queue.MaxAttempts < 1 {
+		errs = append(errs, fmt.Errorf("queue.max_attempts (INBOUND_QUEUE_MAX_ATTEMPTS) must be at least 1"))
+	}
+	if c.Queue.LeaseSeconds < 10 {
+		errs = append(errs, fmt.Errorf("queue.lease_seconds (INBOUND_QUEUE_LEASE_SECONDS) must be at least 10"))
+	}
+	if c.Queue.DedupTTLHours < 1 {
+		errs = append(errs, fmt.Errorf("queue.dedup_ttl_hours (INBOUND_DEDUP_TTL_HOURS) must be at least 1"))
+	}
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// applyEnv overrides every field tagged with `env` whose variable is set.
+// Nested structs are walked recursively; parse failures are collected and returned together.
+func applyEnv(cfg *Config) error {
+	var errs []error
+	applyEnvToStruct(reflect.ValueOf(cfg).Elem(), &errs)
+	return errors.Join(errs...)
+}
+
+func applyEnvToStruct(value reflect.Value, errs *[]error) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		fieldType := value.Type().Field(i)
+
+		if field.Kind() == reflect.Struct {
+			applyEnvToStruct(field, errs)
+			continue
+		}
+
+		key := fieldType.Tag.Get("env")
+		if key == "" {
+			continue
+		}
+
+		raw, ok := os.LookupEnv(key)
+		if !ok || raw == "" {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(raw)
+		case reflect.Int:
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				*errs = append(*errs, fmt.Errorf("%s must be an integer, got %q", key, raw))
+				continue
+			}
+			field.SetInt(int64(parsed))
+		case reflect.Bool:
+			parsed, err := strconv.ParseBool(raw)
+			if err != nil {
+				*errs = append(*errs, fmt.Errorf("%s must be a boolean, got %q", key, raw))
+				continue
+			}
+			field.SetBool(parsed)
+		case reflect.Slice:
+			items := []string{}
+			for _, item := range strings.Split(raw, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			field.Set(reflect.ValueOf(items))
+		default:
+			*errs = append(*errs, fmt.Errorf("%s has unsupported type %s", key, field.Kind()))
+		}
+	}
+}
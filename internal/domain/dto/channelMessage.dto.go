@@ -0,0 +1,119 @@
+package dto
+
+import "fmt"
+
+// ChannelCapabilities tells which kinds of outbound message a channel can deliver, so the
+// pipeline can degrade to plain text on messengers without buttons, media or templates.
+type ChannelCapabilities struct {
+	Text      bool `json:"text"`
+	Audio     bool `json:"audio"`
+	Media     bool `json:"media"`
+	Location  bool `json:"location"`
+	Contacts  bool `json:"contacts"`
+	Buttons   bool `json:"buttons"`
+	List      bool `json:"list"`
+	Templates bool `json:"templates"`
+}
+
+// Supports reports whether the channel can deliver the given payload type (OUTBOUND_PAYLOAD_*).
+func (th ChannelCapabilities) Supports(payloadType string) bool {
+	switch payloadType {
+	case OUTBOUND_PAYLOAD_TEXT:
+		return th.Text
+	case OUTBOUND_PAYLOAD_AUDIO:
+		return th.Audio
+	case OUTBOUND_PAYLOAD_MEDIA:
+		return th.Media
+	case OUTBOUND_PAYLOAD_LOCATION:
+		return th.Location
+	case OUTBOUND_PAYLOAD_CONTACTS:
+		return th.Contacts
+	case OUTBOUND_PAYLOAD_BUTTONS:
+		return th.Buttons
+	case OUTBOUND_PAYLOAD_LIST:
+		return th.List
+	case OUTBOUND_PAYLOAD_TEMPLATE:
+		return th.Templates
+	default:
+		return false
+	}
+}
+
+// ChannelMessage is the channel agnostic outbound message. Type selects the payload
+// (OUTBOUND_PAYLOAD_*) and only the matching field is read.
+type ChannelMessage struct {
+	Type     string            `json:"type"`
+	Text     string            `json:"text,omitempty"`
+	Media    *OutboundMedia    `json:"media,omitempty"`
+	Location *OutboundLocation `json:"location,omitempty"`
+	Contacts []OutboundContact `json:"contacts,omitempty"`
+	Template *TemplateMessage  `json:"template,omitempty"`
+	Buttons  *ButtonsMessage   `json:"buttons,omitempty"`
+	List     *ListMessage      `json:"list,omitempty"`
+}
+
+// Validate checks that the field matching Type is set.
+func (th *ChannelMessage) Validate() error {
+	switch th.Type {
+	case OUTBOUND_PAYLOAD_TEXT:
+		if th.Text == "" {
+			return fmt.Errorf("text message cannot be empty")
+		}
+	case OUTBOUND_PAYLOAD_AUDIO, OUTBOUND_PAYLOAD_MEDIA:
+		if th.Media == nil {
+			return fmt.Errorf("%s message requires media", th.Type)
+		}
+	case OUTBOUND_PAYLOAD_LOCATION:
+		if th.Location == nil {
+			return fmt.Errorf("location message requires a location")
+		}
+	case OUTBOUND_PAYLOAD_CONTACTS:
+		if len(th.Contacts) == 0 {
+			return fmt.Errorf("contacts message requires at least one contact")
+		}
+	case OUTBOUND_PAYLOAD_TEMPLATE:
+		if th.Template == nil {
+			return fmt.Errorf("template message requires a template")
+		}
+	case OUTBOUND_PAYLOAD_BUTTONS:
+		if th.Buttons == nil {
+			return fmt.Errorf("buttons message requires buttons")
+		}
+	case OUTBOUND_PAYLOAD_LIST:
+		if th.List == nil {
+			return fmt.Errorf("list message requires a list")
+		}
+	default:
+		return fmt.Errorf("unknown message type %q", th.Type)
+	}
+	return nil
+}
+
+// Summary is the content stored in the outbound message log for the message.
+func (th *ChannelMessage) Summary() string {
+	switch th.Type {
+	case OUTBOUND_PAYLOAD_AUDIO, OUTBOUND_PAYLOAD_MEDIA:
+		if th.Media.Link != "" {
+			return th.Media.Link
+		}
+		return th.Media.ID
+	case OUTBOUND_PAYLOAD_LOCATION:
+		return fmt.Sprintf("%f,%f", th.Location.Latitude, th.Location.Longitude)
+	case OUTBOUND_PAYLOAD_CONTACTS:
+		return th.Contacts[0].FormattedName
+	case OUTBOUND_PAYLOAD_TEMPLATE:
+		return th.Template.Name
+	case OUTBOUND_PAYLOAD_BUTTONS:
+		return th.Buttons.Body
+	case OUTBOUND_PAYLOAD_LIST:
+		return th.List.Body
+	default:
+		return th.Text
+	}
+}
+
+// ChannelInfo describes a registered channel for capabilities discovery.
+type ChannelInfo struct {
+	Name         string              `json:"name"`
+	Capabilities ChannelCapabilities `json:"capabilities"`
+}
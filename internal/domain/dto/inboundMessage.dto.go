@@ -0,0 +1,130 @@
+package dto
+
+import (
+	"social-connector/internal/util/phone"
+	"time"
+)
+
+const (
+	PROVIDER_META      = "meta"
+	PROVIDER_INFOBIP   = "infobip"
+	PROVIDER_TELEGRAM  = "telegram"
+	PROVIDER_MESSENGER = "messenger"
+	PROVIDER_INSTAGRAM = "instagram"
+	PROVIDER_WEBCHAT   = "webchat"
+)
+
+const (
+	INBOUND_MESSAGE_TEXT         = "text"
+	INBOUND_MESSAGE_AUDIO        = "audio"
+	INBOUND_MESSAGE_IMAGE        = "image"
+	INBOUND_MESSAGE_VIDEO        = "video"
+	INBOUND_MESSAGE_DOCUMENT     = "document"
+	INBOUND_MESSAGE_STICKER      = "sticker"
+	INBOUND_MESSAGE_LOCATION     = "location"
+	INBOUND_MESSAGE_CONTACTS     = "contacts"
+	INBOUND_MESSAGE_BUTTON_REPLY = "button_reply"
+	INBOUND_MESSAGE_LIST_REPLY   = "list_reply"
+	INBOUND_MESSAGE_REACTION     = "reaction"
+	INBOUND_MESSAGE_UNSUPPORTED  = "unsupported"
+)
+
+// InboundMessage is the provider agnostic representation of a message received
+// through any webhook. Every provider payload is mapped into this type before
+// it reaches the channel pipeline.
+type InboundMessage struct {
+	ID             string    `json:"id" bson:"id"`
+	Provider       string    `json:"provider" bson:"provider"`
+	ConversationID string    `json:"conversation_id" bson:"conversation_id"`
+	From           string    `json:"from" bson:"from"`
+	ReplyTo        string    `json:"reply_to" bson:"reply_to"`
+	ContactName    string    `json:"contact_name" bson:"contact_name"`
+	Type           string    `json:"type" bson:"type"`
+	Text           string    `json:"text" bson:"text"`
+	MediaURL       string    `json:"media_url" bson:"media_url"`
+	StoredMediaID  string    `json:"stored_media_id,omitempty" bson:"stored_media_id,omitempty"`
+	ReceivedAt     time.Time `json:"received_at" bson:"received_at"`
+
+	ContextMessageID string           `json:"context_message_id,omitempty" bson:"context_message_id,omitempty"`
+	Media            *InboundMedia    `json:"media,omitempty" bson:"media,omitempty"`
+	Location         *InboundLocation `json:"location,omitempty" bson:"location,omitempty"`
+	Contacts         []InboundContact `json:"contacts,omitempty" bson:"contacts,omitempty"`
+	Reply            *InboundReply    `json:"reply,omitempty" bson:"reply,omitempty"`
+	Reaction         *InboundReaction `json:"reaction,omitempty" bson:"reaction,omitempty"`
+}
+
+// InboundMedia describes a media attachment. Meta only sends the media ID, Infobip sends a URL.
+type InboundMedia struct {
+	ID       string `json:"id,omitempty" bson:"id,omitempty"`
+	URL      string `json:"url,omitempty" bson:"url,omitempty"`
+	MimeType string `json:"mime_type,omitempty" bson:"mime_type,omitempty"`
+	SHA256   string `json:"sha256,omitempty" bson:"sha256,omitempty"`
+	Caption  string `json:"caption,omitempty" bson:"caption,omitempty"`
+	Filename string `json:"filename,omitempty" bson:"filename,omitempty"`
+	Voice    bool   `json:"voice,omitempty" bson:"voice,omitempty"`
+	Animated bool   `json:"animated,omitempty" bson:"animated,omitempty"`
+}
+
+type InboundLocation struct {
+	Latitude  float64 `json:"latitude" bson:"latitude"`
+	Longitude float64 `json:"longitude" bson:"longitude"`
+	Name      string  `json:"name,omitempty" bson:"name,omitempty"`
+	Address   string  `json:"address,omitempty" bson:"address,omitempty"`
+	URL       string  `json:"url,omitempty" bson:"url,omitempty"`
+}
+
+type InboundContact struct {
+	Name   string   `json:"name" bson:"name"`
+	Phones []string `json:"phones,omitempty" bson:"phones,omitempty"`
+	Emails []string `json:"emails,omitempty" bson:"emails,omitempty"`
+}
+
+// InboundReply is the option picked by the user on a reply button or list message.
+type InboundReply struct {
+	ID          string `json:"id" bson:"id"`
+	Title       string `json:"title" bson:"title"`
+	Description string `json:"description,omitempty" bson:"description,omitempty"`
+}
+
+type InboundReaction struct {
+	MessageID string `json:"message_id" bson:"message_id"`
+	Emoji     string `json:"emoji" bson:"emoji"`
+}
+
+// FormatRecipient formats a phone number the way the provider expects it as a recipient.
+// Numbers that cannot be parsed are returned unchanged so the provider can report the error.
+func FormatRecipient(provider string, raw string) string {
+	number, err := phone.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	if provider == PROVIDER_META {
+		return number.Meta()
+	}
+	return number.Infobip()
+}
+
+// PhoneConversationID returns the conversation ID of a WhatsApp number: its international digits
+// with the Brazilian ninth digit, so a number written with or without it always maps to the same
+// conversation. Numbers that cannot be parsed are returned unchanged.
+func PhoneConversationID(raw string) string {
+	number, err := phone.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return number.WithNinthDigit().Digits()
+}
+
+// LegacyConversationID returns the conversation ID a WhatsApp message had before numbers were
+// normalized with PhoneConversationID: the raw number it came from. It is empty when both match
+// or the message did not come through WhatsApp.
+func LegacyConversationID(message InboundMessage) string {
+	if message.Provider != PROVIDER_META && message.Provider != PROVIDER_INFOBIP {
+		return ""
+	}
+	if message.From == message.ConversationID {
+		return ""
+	}
+	return message.From
+}
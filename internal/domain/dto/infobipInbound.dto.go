@@ -1,5 +1,7 @@
 package dto
 
+import "strings"
+
 type InboundResponse struct {
 	Results             []Result `json:"results"`
 	MessageCount        int      `json:"messageCount"`
@@ -20,9 +22,19 @@ type Result struct {
 }
 
 type Message struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
-	Url  string `json:"url"`
+	Type      string  `json:"type"`
+	Text      string  `json:"text"`
+	Url       string  `json:"url"`
+	Caption   string  `json:"caption,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+
+	ID          string `json:"id,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Payload     string `json:"payload,omitempty"`
 }
 
 type Contact struct {
@@ -33,3 +45,56 @@ type Price struct {
 	PricePerMessage float64 `json:"pricePerMessage"`
 	Currency        string  `json:"currency"`
 }
+
+const INFOBIP_TIME_LAYOUT = "2006-01-02T15:04:05.000-0700"
+
+// ToInboundMessages maps every result of an Infobip inbound payload into the normalized inbound model.
+func (th *InboundResponse) ToInboundMessages() []InboundMessage {
+	messages := []InboundMessage{}
+
+	for _, result := range th.Results {
+		inbound := InboundMessage{
+			ID:             result.MessageID,
+			Provider:       PROVIDER_INFOBIP,
+			ConversationID: PhoneConversationID(result.From),
+			From:           result.From,
+			ReplyTo:        FormatRecipient(PROVIDER_INFOBIP, result.From),
+			ContactName:    result.Contact.Name,
+			Type:           strings.ToLower(result.Message.Type),
+			Text:           result.Message.Text,
+			MediaURL:       result.Message.Url,
+			ReceivedAt:     parseInfobipTime(result.ReceivedAt),
+		}
+
+		switch inbound.Type {
+		case INBOUND_MESSAGE_TEXT:
+		case INBOUND_MESSAGE_AUDIO, INBOUND_MESSAGE_IMAGE, INBOUND_MESSAGE_VIDEO, INBOUND_MESSAGE_DOCUMENT, INBOUND_MESSAGE_STICKER:
+			inbound.Media = &InboundMedia{URL: result.Message.Url, Caption: result.Message.Caption}
+			inbound.Text = result.Message.Caption
+		case INBOUND_MESSAGE_LOCATION:
+			inbound.Location = &InboundLocation{
+				Latitude:  result.Message.Latitude,
+				Longitude: result.Message.Longitude,
+				Name:      result.Message.Name,
+				Address:   result.Message.Address,
+			}
+		case "interactive_button_reply":
+			inbound.Type = INBOUND_MESSAGE_BUTTON_REPLY
+			inbound.Reply = &InboundReply{ID: result.Message.ID, Title: result.Message.Title}
+			inbound.Text = result.Message.Title
+		case "interactive_list_reply":
+			inbound.Type = INBOUND_MESSAGE_LIST_REPLY
+			inbound.Reply = &InboundReply{ID: result.Message.ID, Title: result.Message.Title, Description: result.Message.Description}
+			inbound.Text = result.Message.Title
+		case "button":
+			inbound.Type = INBOUND_MESSAGE_BUTTON_REPLY
+			inbound.Reply = &InboundReply{ID: result.Message.Payload, Title: result.Message.Text}
+		default:
+			inbound.Type = INBOUND_MESSAGE_UNSUPPORTED
+		}
+
+		messages = append(messages, inbound)
+	}
+
+	return messages
+}
@@ -3,17 +3,122 @@ package dto
 type InfobipMessagePayload struct {
 	From         string         `json:"from"`
 	To           string         `json:"to"`
-	MessageID    string         `json:"messageId"`
+	MessageID    string         `json:"messageId,omitempty"`
 	Content      MessageContent `json:"content"`
-	CallbackData string         `json:"callbackData"`
-	NotifyURL    string         `json:"notifyUrl"`
-	URLOptions   URLOptions     `json:"urlOptions"`
+	CallbackData string         `json:"callbackData,omitempty"`
+	NotifyURL    string         `json:"notifyUrl,omitempty"`
+	URLOptions   *URLOptions    `json:"urlOptions,omitempty"`
 }
 
 type MessageContent struct {
+	Text     string `json:"text,omitempty"`
+	MediaUrl string `json:"mediaUrl,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+	Filename string `json:"filename,omitempty"`
+
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Address   string   `json:"address,omitempty"`
+
+	Contacts []InfobipContact `json:"contacts,omitempty"`
+
+	Header *InfobipInteractiveText   `json:"header,omitempty"`
+	Body   *InfobipInteractiveText   `json:"body,omitempty"`
+	Footer *InfobipInteractiveText   `json:"footer,omitempty"`
+	Action *InfobipInteractiveAction `json:"action,omitempty"`
+}
+
+type InfobipContact struct {
+	Name   InfobipContactName    `json:"name"`
+	Org    *InfobipContactOrg    `json:"org,omitempty"`
+	Phones []InfobipContactPhone `json:"phones,omitempty"`
+	Emails []InfobipContactEmail `json:"emails,omitempty"`
+}
+
+type InfobipContactName struct {
+	FirstName     string `json:"firstName"`
+	LastName      string `json:"lastName,omitempty"`
+	FormattedName string `json:"formattedName"`
+}
+
+type InfobipContactOrg struct {
+	Company string `json:"company"`
+}
+
+type InfobipContactPhone struct {
+	Phone string `json:"phone"`
+	Type  string `json:"type,omitempty"`
+	WaID  string `json:"waId,omitempty"`
+}
+
+type InfobipContactEmail struct {
+	Email string `json:"email"`
+	Type  string `json:"type,omitempty"`
+}
+
+type InfobipInteractiveText struct {
+	Type string `json:"type,omitempty"`
 	Text string `json:"text"`
 }
 
+type InfobipInteractiveAction struct {
+	Title    string                      `json:"title,omitempty"`
+	Buttons  []InfobipInteractiveButton  `json:"buttons,omitempty"`
+	Sections []InfobipInteractiveSection `json:"sections,omitempty"`
+}
+
+type InfobipInteractiveButton struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type InfobipInteractiveSection struct {
+	Title string    `json:"title,omitempty"`
+	Rows  []ListRow `json:"rows"`
+}
+
+type InfobipTemplatePayload struct {
+	Messages []InfobipTemplateMessage `json:"messages"`
+}
+
+type InfobipTemplateMessage struct {
+	From         string                 `json:"from"`
+	To           string                 `json:"to"`
+	Content      InfobipTemplateContent `json:"content"`
+	CallbackData string                 `json:"callbackData,omitempty"`
+	NotifyURL    string                 `json:"notifyUrl,omitempty"`
+}
+
+type InfobipTemplateContent struct {
+	TemplateName string              `json:"templateName"`
+	TemplateData InfobipTemplateData `json:"templateData"`
+	Language     string              `json:"language"`
+}
+
+type InfobipTemplateData struct {
+	Body    InfobipTemplateBody     `json:"body"`
+	Header  *InfobipTemplateHeader  `json:"header,omitempty"`
+	Buttons []InfobipTemplateButton `json:"buttons,omitempty"`
+}
+
+type InfobipTemplateBody struct {
+	Placeholders []string `json:"placeholders"`
+}
+
+type InfobipTemplateHeader struct {
+	Type        string `json:"type"`
+	Placeholder string `json:"placeholder,omitempty"`
+	MediaURL    string `json:"mediaUrl,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+}
+
+type InfobipTemplateButton struct {
+	Type      string `json:"type"`
+	Parameter string `json:"parameter"`
+}
+
 type URLOptions struct {
 	ShortenURL     bool   `json:"shortenUrl"`
 	TrackClicks    bool   `json:"trackClicks"`
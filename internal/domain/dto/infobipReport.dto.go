@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+)
+
+// InfobipReportResponse is the payload Infobip posts to the notify URL of an outbound
+// message. Delivery reports carry a status, seen reports carry a seenAt timestamp.
+type InfobipReportResponse struct {
+	Results []InfobipReport `json:"results"`
+}
+
+type InfobipReport struct {
+	BulkID       string               `json:"bulkId"`
+	MessageID    string               `json:"messageId"`
+	From         string               `json:"from"`
+	To           string               `json:"to"`
+	SentAt       string               `json:"sentAt"`
+	DoneAt       string               `json:"doneAt"`
+	SeenAt       string               `json:"seenAt"`
+	MessageCount int                  `json:"messageCount"`
+	CallbackData string               `json:"callbackData"`
+	Price        *Price               `json:"price"`
+	Status       *InfobipReportStatus `json:"status"`
+	Error        *InfobipReportStatus `json:"error"`
+}
+
+type InfobipReportStatus struct {
+	GroupID     int    `json:"groupId"`
+	GroupName   string `json:"groupName"`
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Permanent   bool   `json:"permanent"`
+}
+
+// ToStatusEvents maps every delivery or seen report of an Infobip payload into status events.
+// The callback data sent with the message carries the recipient, normalized into the
+// conversation ID the same way inbound messages are.
+func (th *InfobipReportResponse) ToStatusEvents() []MessageStatusEvent {
+	events := []MessageStatusEvent{}
+
+	for _, report := range th.Results {
+		event := MessageStatusEvent{
+			Provider:          PROVIDER_INFOBIP,
+			ProviderMessageID: report.MessageID,
+			ConversationID:    PhoneConversationID(report.CallbackData),
+			Recipient:         report.To,
+			CallbackData:      report.CallbackData,
+		}
+		if report.CallbackData == "" {
+			event.ConversationID = PhoneConversationID(report.To)
+		}
+
+		if report.SeenAt != "" {
+			event.Status = MESSAGE_STATUS_READ
+			event.Timestamp = parseInfobipTime(report.SeenAt)
+			events = append(events, event)
+			continue
+		}
+
+		event.Status = report.status()
+		event.Timestamp = parseInfobipTime(report.DoneAt)
+
+		if report.Error != nil && report.Error.ID != 0 {
+			event.Errors = append(event.Errors, MessageStatusError{
+				Code:    report.Error.ID,
+				Title:   report.Error.Name,
+				Message: report.Error.Description,
+				Details: fmt.Sprintf("%s, permanent: %t", report.Error.GroupName, report.Error.Permanent),
+			})
+		}
+
+		if report.Price != nil {
+			event.Pricing = &MessageStatusPricing{
+				Billable: report.Price.PricePerMessage > 0,
+				Price:    report.Price.PricePerMessage,
+				Currency: report.Price.Currency,
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// status maps the Infobip status group into the connector status.
+func (th *InfobipReport) status() string {
+	if th.Status == nil {
+		return MESSAGE_STATUS_SENT
+	}
+
+	switch th.Status.GroupName {
+	case "DELIVERED":
+		return MESSAGE_STATUS_DELIVERED
+	case "UNDELIVERABLE", "EXPIRED", "REJECTED":
+		return MESSAGE_STATUS_FAILED
+	default:
+		return MESSAGE_STATUS_SENT
+	}
+}
+
+func parseInfobipTime(value string) time.Time {
+	parsed, err := time.Parse(INFOBIP_TIME_LAYOUT, value)
+	if err != nil {
+		return time.Now()
+	}
+	return parsed
+}
@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// WhatsApp limits for interactive messages, enforced before sending so the provider does not reject the message.
+const (
+	MAX_REPLY_BUTTONS        = 3
+	MAX_REPLY_BUTTON_TITLE   = 20
+	MAX_LIST_ROWS            = 10
+	MAX_LIST_BUTTON_TEXT     = 20
+	MAX_LIST_ROW_TITLE       = 24
+	MAX_LIST_ROW_DESCRIPTION = 72
+)
+
+// ButtonsMessage is a message with up to three reply buttons. The ID of the chosen button
+// comes back as the Reply of a button_reply inbound message.
+type ButtonsMessage struct {
+	Header  string        `json:"header,omitempty"`
+	Body    string        `json:"body"`
+	Footer  string        `json:"footer,omitempty"`
+	Buttons []ReplyButton `json:"buttons"`
+}
+
+type ReplyButton struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// ListMessage is a message that opens a list of options grouped in sections. The ID of the
+// chosen row comes back as the Reply of a list_reply inbound message.
+type ListMessage struct {
+	Header     string        `json:"header,omitempty"`
+	Body       string        `json:"body"`
+	Footer     string        `json:"footer,omitempty"`
+	ButtonText string        `json:"button_text"`
+	Sections   []ListSection `json:"sections"`
+}
+
+type ListSection struct {
+	Title string    `json:"title,omitempty"`
+	Rows  []ListRow `json:"rows"`
+}
+
+type ListRow struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+func (th *ButtonsMessage) Validate() error {
+	if th.Body == "" {
+		return fmt.Errorf("buttons message body cannot be empty")
+	}
+	if len(th.Buttons) == 0 || len(th.Buttons) > MAX_REPLY_BUTTONS {
+		return fmt.Errorf("buttons message needs 1 to %d buttons, got %d", MAX_REPLY_BUTTONS, len(th.Buttons))
+	}
+	for _, button := range th.Buttons {
+		if button.ID == "" || button.Title == "" {
+			return fmt.Errorf("reply buttons need an id and a title")
+		}
+		if utf8.RuneCountInString(button.Title) > MAX_REPLY_BUTTON_TITLE {
+			return fmt.Errorf("reply button title %q is longer than %d characters", button.Title, MAX_REPLY_BUTTON_TITLE)
+		}
+	}
+	return nil
+}
+
+func (th *ListMessage) Validate() error {
+	if th.Body == "" {
+		return fmt.Errorf("list message body cannot be empty")
+	}
+	if th.ButtonText == "" || utf8.RuneCountInString(th.ButtonText) > MAX_LIST_BUTTON_TEXT {
+		return fmt.Errorf("list button text must have 1 to %d characters", MAX_LIST_BUTTON_TEXT)
+	}
+
+	rows := 0
+	for _, section := range th.Sections {
+		for _, row := range section.Rows {
+			if row.ID == "" || row.Title == "" {
+				return fmt.Errorf("list rows need an id and a title")
+			}
+			if utf8.RuneCountInString(row.Title) > MAX_LIST_ROW_TITLE {
+				return fmt.Errorf("list row title %q is longer than %d characters", row.Title, MAX_LIST_ROW_TITLE)
+			}
+			if utf8.RuneCountInString(row.Description) > MAX_LIST_ROW_DESCRIPTION {
+				return fmt.Errorf("list row description of %q is longer than %d characters", row.Title, MAX_LIST_ROW_DESCRIPTION)
+			}
+			rows++
+		}
+	}
+	if rows == 0 || rows > MAX_LIST_ROWS {
+		return fmt.Errorf("list message needs 1 to %d rows, got %d", MAX_LIST_ROWS, rows)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "io"
+
+// DownloadedMedia is an inbound media file fetched from a provider. The caller closes Body.
+type DownloadedMedia struct {
+	Body     io.ReadCloser
+	MimeType string
+	Filename string
+}
+
+// MetaMediaInfo is the Graph API response resolving a media ID into a short-lived download URL.
+type MetaMediaInfo struct {
+	ID       string `json:"id"`
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	SHA256   string `json:"sha256"`
+	FileSize int64  `json:"file_size"`
+}
@@ -0,0 +1,66 @@
+package dto
+
+import "time"
+
+const (
+	MESSAGE_STATUS_ACCEPTED  = "accepted"
+	MESSAGE_STATUS_SENT      = "sent"
+	MESSAGE_STATUS_DELIVERED = "delivered"
+	MESSAGE_STATUS_READ      = "read"
+	MESSAGE_STATUS_FAILED    = "failed"
+)
+
+// MessageStatusEvent is the provider agnostic representation of a delivery status
+// notification for a message sent by the connector.
+type MessageStatusEvent struct {
+	Provider          string                     `json:"provider" bson:"provider"`
+	ProviderMessageID string                     `json:"provider_message_id" bson:"provider_message_id"`
+	ConversationID    string                     `json:"conversation_id" bson:"conversation_id"`
+	Recipient         string                     `json:"recipient" bson:"recipient"`
+	Status            string                     `json:"status" bson:"status"`
+	Timestamp         time.Time                  `json:"timestamp" bson:"timestamp"`
+	CallbackData      string                     `json:"callback_data,omitempty" bson:"callback_data,omitempty"`
+	Errors            []MessageStatusError       `json:"errors,omitempty" bson:"errors,omitempty"`
+	Pricing           *MessageStatusPricing      `json:"pricing,omitempty" bson:"pricing,omitempty"`
+	Conversation      *MessageStatusConversation `json:"conversation,omitempty" bson:"conversation,omitempty"`
+}
+
+type MessageStatusError struct {
+	Code    int    `json:"code" bson:"code"`
+	Title   string `json:"title" bson:"title"`
+	Message string `json:"message,omitempty" bson:"message,omitempty"`
+	Details string `json:"details,omitempty" bson:"details,omitempty"`
+}
+
+type MessageStatusPricing struct {
+	Billable bool    `json:"billable" bson:"billable"`
+	Model    string  `json:"model,omitempty" bson:"model,omitempty"`
+	Category string  `json:"category,omitempty" bson:"category,omitempty"`
+	Price    float64 `json:"price,omitempty" bson:"price,omitempty"`
+	Currency string  `json:"currency,omitempty" bson:"currency,omitempty"`
+}
+
+type MessageStatusConversation struct {
+	ID         string     `json:"id" bson:"id"`
+	OriginType string     `json:"origin_type,omitempty" bson:"origin_type,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+}
+
+// StatusRank orders statuses so a late "delivered" does not overwrite an earlier "read".
+// Failures always win.
+func StatusRank(status string) int {
+	switch status {
+	case MESSAGE_STATUS_ACCEPTED:
+		return 1
+	case MESSAGE_STATUS_SENT:
+		return 2
+	case MESSAGE_STATUS_DELIVERED:
+		return 3
+	case MESSAGE_STATUS_READ:
+		return 4
+	case MESSAGE_STATUS_FAILED:
+		return 5
+	default:
+		return 0
+	}
+}
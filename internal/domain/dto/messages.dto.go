@@ -1,13 +1,22 @@
 package dto
 
+import (
+	"strconv"
+	"time"
+)
+
 type IWebhookMessage struct {
 	Object string         `json:"object"`
 	Entry  []WebhookEntry `json:"entry"`
 }
 
+// WebhookEntry carries Changes for WhatsApp Business Accounts and Messaging events for
+// Messenger pages and Instagram accounts.
 type WebhookEntry struct {
-	ID      string          `json:"id"`
-	Changes []WebhookChange `json:"changes"`
+	ID        string           `json:"id"`
+	Time      int64            `json:"time,omitempty"`
+	Changes   []WebhookChange  `json:"changes"`
+	Messaging []MessagingEvent `json:"messaging,omitempty"`
 }
 
 type WebhookChange struct {
@@ -20,6 +29,7 @@ type WebhookValue struct {
 	Metadata         WebhookMetadata      `json:"metadata"`
 	Contacts         []WebhookContact     `json:"contacts"`
 	Messages         []WebhookMessageData `json:"messages"`
+	Statuses         []WebhookStatus      `json:"statuses"`
 }
 
 type WebhookMetadata struct {
@@ -37,26 +47,422 @@ type WebhookContactProfile struct {
 }
 
 type WebhookMessageData struct {
-	From      string      `json:"from"`
-	ID        string      `json:"id"`
-	Timestamp string      `json:"timestamp"`
-	Text      WebhookText `json:"text"`
-	Type      string      `json:"type"`
+	From        string                 `json:"from"`
+	ID          string                 `json:"id"`
+	Timestamp   string                 `json:"timestamp"`
+	Text        WebhookText            `json:"text"`
+	Type        string                 `json:"type"`
+	Context     *WebhookMessageContext `json:"context,omitempty"`
+	Image       *WebhookMedia          `json:"image,omitempty"`
+	Audio       *WebhookMedia          `json:"audio,omitempty"`
+	Video       *WebhookMedia          `json:"video,omitempty"`
+	Document    *WebhookMedia          `json:"document,omitempty"`
+	Sticker     *WebhookMedia          `json:"sticker,omitempty"`
+	Location    *WebhookLocation       `json:"location,omitempty"`
+	Contacts    []WebhookSharedContact `json:"contacts,omitempty"`
+	Interactive *WebhookInteractive    `json:"interactive,omitempty"`
+	Button      *WebhookButton         `json:"button,omitempty"`
+	Reaction    *WebhookReaction       `json:"reaction,omitempty"`
+	Errors      []WebhookError         `json:"errors,omitempty"`
 }
 
 type WebhookText struct {
 	Body string `json:"body"`
 }
 
+type WebhookMessageContext struct {
+	From string `json:"from"`
+	ID   string `json:"id"`
+}
+
+type WebhookMedia struct {
+	ID       string `json:"id"`
+	MimeType string `json:"mime_type"`
+	SHA256   string `json:"sha256"`
+	Caption  string `json:"caption,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	Voice    bool   `json:"voice,omitempty"`
+	Animated bool   `json:"animated,omitempty"`
+}
+
+type WebhookLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+	URL       string  `json:"url,omitempty"`
+}
+
+type WebhookSharedContact struct {
+	Name struct {
+		FormattedName string `json:"formatted_name"`
+		FirstName     string `json:"first_name,omitempty"`
+		LastName      string `json:"last_name,omitempty"`
+	} `json:"name"`
+	Phones []struct {
+		Phone string `json:"phone"`
+		Type  string `json:"type,omitempty"`
+		WaID  string `json:"wa_id,omitempty"`
+	} `json:"phones,omitempty"`
+	Emails []struct {
+		Email string `json:"email"`
+		Type  string `json:"type,omitempty"`
+	} `json:"emails,omitempty"`
+}
+
+type WebhookInteractive struct {
+	Type        string              `json:"type"`
+	ButtonReply *WebhookReplyOption `json:"button_reply,omitempty"`
+	ListReply   *WebhookReplyOption `json:"list_reply,omitempty"`
+}
+
+type WebhookReplyOption struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+type WebhookButton struct {
+	Payload string `json:"payload"`
+	Text    string `json:"text"`
+}
+
+type WebhookReaction struct {
+	MessageID string `json:"message_id"`
+	Emoji     string `json:"emoji"`
+}
+
+type WebhookError struct {
+	Code      int    `json:"code"`
+	Title     string `json:"title"`
+	Message   string `json:"message,omitempty"`
+	ErrorData struct {
+		Details string `json:"details"`
+	} `json:"error_data,omitempty"`
+}
+
+type WebhookStatus struct {
+	ID                    string               `json:"id"`
+	Status                string               `json:"status"`
+	Timestamp             string               `json:"timestamp"`
+	RecipientID           string               `json:"recipient_id"`
+	BizOpaqueCallbackData string               `json:"biz_opaque_callback_data,omitempty"`
+	Conversation          *WebhookConversation `json:"conversation,omitempty"`
+	Pricing               *WebhookPricing      `json:"pricing,omitempty"`
+	Errors                []WebhookError       `json:"errors,omitempty"`
+}
+
+type WebhookConversation struct {
+	ID                  string `json:"id"`
+	ExpirationTimestamp string `json:"expiration_timestamp,omitempty"`
+	Origin              struct {
+		Type string `json:"type"`
+	} `json:"origin"`
+}
+
+type WebhookPricing struct {
+	Billable     bool   `json:"billable"`
+	PricingModel string `json:"pricing_model"`
+	Category     string `json:"category"`
+}
+
 type IWhatsAppMessage struct {
-	MessagingProduct string              `json:"messaging_product"`
-	RecipientType    string              `json:"recipient_type"`
-	To               string              `json:"to"`
-	Type             string              `json:"type"`
-	Text             whatsAppMessageText `json:"text"`
+	MessagingProduct string               `json:"messaging_product"`
+	RecipientType    string               `json:"recipient_type"`
+	To               string               `json:"to"`
+	Type             string               `json:"type"`
+	Text             *WhatsAppMessageText `json:"text,omitempty"`
+	Audio            *WhatsAppMediaObject `json:"audio,omitempty"`
+	Image            *WhatsAppMediaObject `json:"image,omitempty"`
+	Video            *WhatsAppMediaObject `json:"video,omitempty"`
+	Document         *WhatsAppMediaObject `json:"document,omitempty"`
+	Sticker          *WhatsAppMediaObject `json:"sticker,omitempty"`
+	Location         *OutboundLocation    `json:"location,omitempty"`
+	Contacts         []WhatsAppContact    `json:"contacts,omitempty"`
+	Template         *WhatsAppTemplate    `json:"template,omitempty"`
+	Interactive      *WhatsAppInteractive `json:"interactive,omitempty"`
 }
 
-type whatsAppMessageText struct {
+type WhatsAppMessageText struct {
 	PreviewURL bool   `json:"preview_url"`
 	Body       string `json:"body"`
 }
+
+type WhatsAppMediaObject struct {
+	ID       string `json:"id,omitempty"`
+	Link     string `json:"link,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+type WhatsAppContact struct {
+	Name   WhatsAppContactName `json:"name"`
+	Org    *WhatsAppContactOrg `json:"org,omitempty"`
+	Phones []ContactPhone      `json:"phones,omitempty"`
+	Emails []ContactEmail      `json:"emails,omitempty"`
+}
+
+type WhatsAppContactName struct {
+	FormattedName string `json:"formatted_name"`
+	FirstName     string `json:"first_name,omitempty"`
+	LastName      string `json:"last_name,omitempty"`
+}
+
+type WhatsAppContactOrg struct {
+	Company string `json:"company"`
+}
+
+type WhatsAppInteractive struct {
+	Type   string                    `json:"type"`
+	Header *WhatsAppInteractiveText  `json:"header,omitempty"`
+	Body   WhatsAppInteractiveText   `json:"body"`
+	Footer *WhatsAppInteractiveText  `json:"footer,omitempty"`
+	Action WhatsAppInteractiveAction `json:"action"`
+}
+
+type WhatsAppInteractiveText struct {
+	Type string `json:"type,omitempty"`
+	Text string `json:"text"`
+}
+
+type WhatsAppInteractiveAction struct {
+	Button   string                       `json:"button,omitempty"`
+	Buttons  []WhatsAppInteractiveButton  `json:"buttons,omitempty"`
+	Sections []WhatsAppInteractiveSection `json:"sections,omitempty"`
+}
+
+type WhatsAppInteractiveButton struct {
+	Type  string      `json:"type"`
+	Reply ReplyButton `json:"reply"`
+}
+
+type WhatsAppInteractiveSection struct {
+	Title string    `json:"title,omitempty"`
+	Rows  []ListRow `json:"rows"`
+}
+
+type WhatsAppTemplate struct {
+	Name       string                      `json:"name"`
+	Language   WhatsAppTemplateLanguage    `json:"language"`
+	Components []WhatsAppTemplateComponent `json:"components,omitempty"`
+}
+
+type WhatsAppTemplateLanguage struct {
+	Code string `json:"code"`
+}
+
+type WhatsAppTemplateComponent struct {
+	Type       string                      `json:"type"`
+	SubType    string                      `json:"sub_type,omitempty"`
+	Index      string                      `json:"index,omitempty"`
+	Parameters []WhatsAppTemplateParameter `json:"parameters"`
+}
+
+type WhatsAppTemplateParameter struct {
+	Type     string               `json:"type"`
+	Text     string               `json:"text,omitempty"`
+	Payload  string               `json:"payload,omitempty"`
+	Image    *WhatsAppMediaObject `json:"image,omitempty"`
+	Video    *WhatsAppMediaObject `json:"video,omitempty"`
+	Document *WhatsAppMediaObject `json:"document,omitempty"`
+}
+
+// ToInboundMessages maps every message of a Meta webhook payload (WhatsApp, Messenger or
+// Instagram) into the normalized inbound model.
+func (th *IWebhookMessage) ToInboundMessages() []InboundMessage {
+	messages := []InboundMessage{}
+
+	for _, entry := range th.Entry {
+		messages = append(messages, entry.messagingInboundMessages(th.Object)...)
+
+		for _, change := range entry.Changes {
+			contactNames := map[string]string{}
+			for _, contact := range change.Value.Contacts {
+				contactNames[contact.WaID] = contact.Profile.Name
+			}
+
+			for _, message := range change.Value.Messages {
+				receivedAt := parseUnixTimestamp(message.Timestamp)
+
+				inbound := InboundMessage{
+					ID:             message.ID,
+					Provider:       PROVIDER_META,
+					ConversationID: PhoneConversationID(message.From),
+					From:           message.From,
+					ReplyTo:        FormatRecipient(PROVIDER_META, message.From),
+					ContactName:    contactNames[message.From],
+					Type:           message.Type,
+					Text:           message.Text.Body,
+					ReceivedAt:     receivedAt,
+				}
+				if message.Context != nil {
+					inbound.ContextMessageID = message.Context.ID
+				}
+				message.normalize(&inbound)
+
+				messages = append(messages, inbound)
+			}
+		}
+	}
+
+	return messages
+}
+
+// normalize fills the type specific fields of the normalized message from a Meta message.
+// Types this connector does not know are reported as INBOUND_MESSAGE_UNSUPPORTED.
+func (th *WebhookMessageData) normalize(inbound *InboundMessage) {
+	media := func(m *WebhookMedia) *InboundMedia {
+		if m == nil {
+			return nil
+		}
+		return &InboundMedia{
+			ID:       m.ID,
+			MimeType: m.MimeType,
+			SHA256:   m.SHA256,
+			Caption:  m.Caption,
+			Filename: m.Filename,
+			Voice:    m.Voice,
+			Animated: m.Animated,
+		}
+	}
+
+	switch th.Type {
+	case "text":
+		inbound.Type = INBOUND_MESSAGE_TEXT
+	case "image":
+		inbound.Type = INBOUND_MESSAGE_IMAGE
+		inbound.Media = media(th.Image)
+	case "audio":
+		inbound.Type = INBOUND_MESSAGE_AUDIO
+		inbound.Media = media(th.Audio)
+	case "video":
+		inbound.Type = INBOUND_MESSAGE_VIDEO
+		inbound.Media = media(th.Video)
+	case "document":
+		inbound.Type = INBOUND_MESSAGE_DOCUMENT
+		inbound.Media = media(th.Document)
+	case "sticker":
+		inbound.Type = INBOUND_MESSAGE_STICKER
+		inbound.Media = media(th.Sticker)
+	case "location":
+		inbound.Type = INBOUND_MESSAGE_LOCATION
+		if th.Location != nil {
+			inbound.Location = &InboundLocation{
+				Latitude:  th.Location.Latitude,
+				Longitude: th.Location.Longitude,
+				Name:      th.Location.Name,
+				Address:   th.Location.Address,
+				URL:       th.Location.URL,
+			}
+		}
+	case "contacts":
+		inbound.Type = INBOUND_MESSAGE_CONTACTS
+		for _, contact := range th.Contacts {
+			shared := InboundContact{Name: contact.Name.FormattedName}
+			for _, phone := range contact.Phones {
+				shared.Phones = append(shared.Phones, phone.Phone)
+			}
+			for _, email := range contact.Emails {
+				shared.Emails = append(shared.Emails, email.Email)
+			}
+			inbound.Contacts = append(inbound.Contacts, shared)
+		}
+	case "interactive":
+		if th.Interactive == nil {
+			inbound.Type = INBOUND_MESSAGE_UNSUPPORTED
+			return
+		}
+		reply := th.Interactive.ButtonReply
+		inbound.Type = INBOUND_MESSAGE_BUTTON_REPLY
+		if th.Interactive.Type == "list_reply" {
+			reply = th.Interactive.ListReply
+			inbound.Type = INBOUND_MESSAGE_LIST_REPLY
+		}
+		if reply == nil {
+			inbound.Type = INBOUND_MESSAGE_UNSUPPORTED
+			return
+		}
+		inbound.Reply = &InboundReply{ID: reply.ID, Title: reply.Title, Description: reply.Description}
+		inbound.Text = reply.Title
+	case "button":
+		inbound.Type = INBOUND_MESSAGE_BUTTON_REPLY
+		if th.Button != nil {
+			inbound.Reply = &InboundReply{ID: th.Button.Payload, Title: th.Button.Text}
+			inbound.Text = th.Button.Text
+		}
+	case "reaction":
+		inbound.Type = INBOUND_MESSAGE_REACTION
+		if th.Reaction != nil {
+			inbound.Reaction = &InboundReaction{MessageID: th.Reaction.MessageID, Emoji: th.Reaction.Emoji}
+		}
+	default:
+		inbound.Type = INBOUND_MESSAGE_UNSUPPORTED
+	}
+
+	if inbound.Media != nil && inbound.Text == "" {
+		inbound.Text = inbound.Media.Caption
+	}
+}
+
+// ToStatusEvents maps every status notification of a Meta webhook payload into status events.
+func (th *IWebhookMessage) ToStatusEvents() []MessageStatusEvent {
+	events := []MessageStatusEvent{}
+
+	for _, entry := range th.Entry {
+		events = append(events, entry.messagingStatusEvents(th.Object)...)
+
+		for _, change := range entry.Changes {
+			for _, status := range change.Value.Statuses {
+				event := MessageStatusEvent{
+					Provider:          PROVIDER_META,
+					ProviderMessageID: status.ID,
+					ConversationID:    PhoneConversationID(status.RecipientID),
+					Recipient:         status.RecipientID,
+					Status:            status.Status,
+					Timestamp:         parseUnixTimestamp(status.Timestamp),
+					CallbackData:      status.BizOpaqueCallbackData,
+				}
+
+				for _, statusError := range status.Errors {
+					event.Errors = append(event.Errors, MessageStatusError{
+						Code:    statusError.Code,
+						Title:   statusError.Title,
+						Message: statusError.Message,
+						Details: statusError.ErrorData.Details,
+					})
+				}
+
+				if status.Pricing != nil {
+					event.Pricing = &MessageStatusPricing{
+						Billable: status.Pricing.Billable,
+						Model:    status.Pricing.PricingModel,
+						Category: status.Pricing.Category,
+					}
+				}
+
+				if status.Conversation != nil {
+					event.Conversation = &MessageStatusConversation{
+						ID:         status.Conversation.ID,
+						OriginType: status.Conversation.Origin.Type,
+					}
+					if status.Conversation.ExpirationTimestamp != "" {
+						expiresAt := parseUnixTimestamp(status.Conversation.ExpirationTimestamp)
+						event.Conversation.ExpiresAt = &expiresAt
+					}
+				}
+
+				events = append(events, event)
+			}
+		}
+	}
+
+	return events
+}
+
+func parseUnixTimestamp(timestamp string) time.Time {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Now()
+	}
+	return time.Unix(seconds, 0)
+}
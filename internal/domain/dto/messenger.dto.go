@@ -0,0 +1,247 @@
+package dto
+
+import (
+	"strings"
+	"time"
+)
+
+// Webhook objects of the Messenger Platform. WhatsApp payloads use "whatsapp_business_account".
+const (
+	MESSENGER_OBJECT_PAGE      = "page"
+	MESSENGER_OBJECT_INSTAGRAM = "instagram"
+)
+
+// Limits of the Send API quick replies.
+const (
+	MAX_QUICK_REPLIES      = 13
+	MAX_QUICK_REPLY_TITLE  = 20
+	MESSENGER_MESSAGE_TYPE = "RESPONSE"
+)
+
+// MessagingEvent is an entry of the messaging array Messenger and Instagram webhooks send.
+// Sender and recipient IDs are page-scoped (PSID) or Instagram-scoped (IGSID).
+type MessagingEvent struct {
+	Sender    MessagingParty     `json:"sender"`
+	Recipient MessagingParty     `json:"recipient"`
+	Timestamp int64              `json:"timestamp"`
+	Message   *MessagingMessage  `json:"message,omitempty"`
+	Postback  *MessagingPostback `json:"postback,omitempty"`
+	Delivery  *MessagingDelivery `json:"delivery,omitempty"`
+	Read      *MessagingRead     `json:"read,omitempty"`
+}
+
+type MessagingParty struct {
+	ID string `json:"id"`
+}
+
+type MessagingMessage struct {
+	MID         string                `json:"mid"`
+	Text        string                `json:"text,omitempty"`
+	IsEcho      bool                  `json:"is_echo,omitempty"`
+	QuickReply  *MessagingQuickReply  `json:"quick_reply,omitempty"`
+	ReplyTo     *MessagingReplyTo     `json:"reply_to,omitempty"`
+	Attachments []MessagingAttachment `json:"attachments,omitempty"`
+}
+
+type MessagingQuickReply struct {
+	Payload string `json:"payload"`
+}
+
+type MessagingReplyTo struct {
+	MID string `json:"mid"`
+}
+
+type MessagingAttachment struct {
+	Type    string                     `json:"type"`
+	Payload MessagingAttachmentPayload `json:"payload"`
+}
+
+type MessagingAttachmentPayload struct {
+	URL       string `json:"url,omitempty"`
+	StickerID int64  `json:"sticker_id,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+type MessagingPostback struct {
+	MID     string `json:"mid,omitempty"`
+	Title   string `json:"title"`
+	Payload string `json:"payload"`
+}
+
+type MessagingDelivery struct {
+	MIDs      []string `json:"mids"`
+	Watermark int64    `json:"watermark"`
+}
+
+type MessagingRead struct {
+	MID       string `json:"mid,omitempty"`
+	Watermark int64  `json:"watermark"`
+}
+
+// MessengerSendRequest is the body of the Send API (POST /me/messages).
+type MessengerSendRequest struct {
+	Recipient     MessagingParty   `json:"recipient"`
+	MessagingType string           `json:"messaging_type"`
+	Message       MessengerMessage `json:"message"`
+}
+
+type MessengerMessage struct {
+	Text         string                `json:"text,omitempty"`
+	Attachment   *MessengerAttachment  `json:"attachment,omitempty"`
+	QuickReplies []MessengerQuickReply `json:"quick_replies,omitempty"`
+}
+
+type MessengerAttachment struct {
+	Type    string                     `json:"type"`
+	Payload MessengerAttachmentPayload `json:"payload"`
+}
+
+type MessengerAttachmentPayload struct {
+	URL          string `json:"url,omitempty"`
+	AttachmentID string `json:"attachment_id,omitempty"`
+	IsReusable   bool   `json:"is_reusable,omitempty"`
+}
+
+type MessengerQuickReply struct {
+	ContentType string `json:"content_type"`
+	Title       string `json:"title"`
+	Payload     string `json:"payload"`
+}
+
+// MessengerSendResponse is the body returned by the Send API.
+type MessengerSendResponse struct {
+	RecipientID string `json:"recipient_id"`
+	MessageID   string `json:"message_id"`
+}
+
+// MessagingProvider returns the provider of a Messenger Platform webhook object, or an empty
+// string for objects that are not delivered in the messaging shape.
+func MessagingProvider(object string) string {
+	switch object {
+	case MESSENGER_OBJECT_PAGE:
+		return PROVIDER_MESSENGER
+	case MESSENGER_OBJECT_INSTAGRAM:
+		return PROVIDER_INSTAGRAM
+	default:
+		return ""
+	}
+}
+
+// MessagingConversationID returns the conversation ID of a page-scoped or Instagram-scoped user.
+func MessagingConversationID(provider string, scopedID string) string {
+	return provider + ":" + scopedID
+}
+
+// messagingInboundMessages maps the messages and postbacks of a Messenger or Instagram entry
+// into the normalized inbound model. Echoes of the page's own messages are skipped.
+func (th *WebhookEntry) messagingInboundMessages(object string) []InboundMessage {
+	messages := []InboundMessage{}
+	provider := MessagingProvider(object)
+	if provider == "" {
+		return messages
+	}
+
+	for _, event := range th.Messaging {
+		inbound := InboundMessage{
+			Provider:       provider,
+			ConversationID: MessagingConversationID(provider, event.Sender.ID),
+			From:           event.Sender.ID,
+			ReplyTo:        event.Sender.ID,
+			Type:           INBOUND_MESSAGE_TEXT,
+			ReceivedAt:     time.UnixMilli(event.Timestamp),
+		}
+
+		switch {
+		case event.Message != nil && !event.Message.IsEcho:
+			event.Message.normalize(&inbound)
+		case event.Postback != nil:
+			inbound.ID = event.Postback.MID
+			inbound.Type = INBOUND_MESSAGE_BUTTON_REPLY
+			inbound.Reply = &InboundReply{ID: event.Postback.Payload, Title: event.Postback.Title}
+			inbound.Text = event.Postback.Title
+		default:
+			continue
+		}
+
+		messages = append(messages, inbound)
+	}
+
+	return messages
+}
+
+// normalize fills the normalized message from a Messenger or Instagram message. Only the first
+// attachment is kept; attachment types this connector does not know are reported as
+// INBOUND_MESSAGE_UNSUPPORTED.
+func (th *MessagingMessage) normalize(inbound *InboundMessage) {
+	inbound.ID = th.MID
+	inbound.Text = th.Text
+	if th.ReplyTo != nil {
+		inbound.ContextMessageID = th.ReplyTo.MID
+	}
+
+	if th.QuickReply != nil {
+		inbound.Type = INBOUND_MESSAGE_BUTTON_REPLY
+		inbound.Reply = &InboundReply{ID: th.QuickReply.Payload, Title: th.Text}
+		return
+	}
+
+	if len(th.Attachments) == 0 {
+		return
+	}
+
+	attachment := th.Attachments[0]
+	switch attachment.Type {
+	case "image":
+		inbound.Type = INBOUND_MESSAGE_IMAGE
+		if attachment.Payload.StickerID != 0 {
+			inbound.Type = INBOUND_MESSAGE_STICKER
+		}
+	case "video":
+		inbound.Type = INBOUND_MESSAGE_VIDEO
+	case "audio":
+		inbound.Type = INBOUND_MESSAGE_AUDIO
+	case "file":
+		inbound.Type = INBOUND_MESSAGE_DOCUMENT
+	default:
+		inbound.Type = INBOUND_MESSAGE_UNSUPPORTED
+		return
+	}
+
+	inbound.MediaURL = attachment.Payload.URL
+	inbound.Media = &InboundMedia{URL: attachment.Payload.URL, Filename: attachment.Payload.Title}
+}
+
+// messagingStatusEvents maps the delivery and read receipts of a Messenger or Instagram entry
+// into status events. Messenger read receipts only carry a watermark, so they are skipped;
+// Instagram read receipts name the message.
+func (th *WebhookEntry) messagingStatusEvents(object string) []MessageStatusEvent {
+	events := []MessageStatusEvent{}
+	provider := MessagingProvider(object)
+	if provider == "" {
+		return events
+	}
+
+	for _, event := range th.Messaging {
+		status := func(mid string, messageStatus string) MessageStatusEvent {
+			return MessageStatusEvent{
+				Provider:          provider,
+				ProviderMessageID: mid,
+				ConversationID:    MessagingConversationID(provider, event.Sender.ID),
+				Recipient:         event.Sender.ID,
+				Status:            messageStatus,
+				Timestamp:         time.UnixMilli(event.Timestamp),
+			}
+		}
+
+		if event.Delivery != nil {
+			for _, mid := range event.Delivery.MIDs {
+				events = append(events, status(mid, MESSAGE_STATUS_DELIVERED))
+			}
+		}
+		if event.Read != nil && strings.TrimSpace(event.Read.MID) != "" {
+			events = append(events, status(event.Read.MID, MESSAGE_STATUS_READ))
+		}
+	}
+
+	return events
+}
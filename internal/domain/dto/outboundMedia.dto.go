@@ -0,0 +1,91 @@
+package dto
+
+import "fmt"
+
+const (
+	OUTBOUND_MEDIA_IMAGE    = "image"
+	OUTBOUND_MEDIA_VIDEO    = "video"
+	OUTBOUND_MEDIA_DOCUMENT = "document"
+	OUTBOUND_MEDIA_STICKER  = "sticker"
+	OUTBOUND_MEDIA_AUDIO    = "audio"
+)
+
+// OutboundMedia is a media attachment to send. Link is a public URL; ID is a media ID
+// uploaded earlier to the provider. One of them is required.
+type OutboundMedia struct {
+	Type     string `json:"type"`
+	ID       string `json:"id,omitempty"`
+	Link     string `json:"link,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+type OutboundLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+}
+
+// OutboundContact is a contact card.
+type OutboundContact struct {
+	FormattedName string         `json:"formatted_name"`
+	FirstName     string         `json:"first_name,omitempty"`
+	LastName      string         `json:"last_name,omitempty"`
+	Company       string         `json:"company,omitempty"`
+	Phones        []ContactPhone `json:"phones,omitempty"`
+	Emails        []ContactEmail `json:"emails,omitempty"`
+}
+
+type ContactPhone struct {
+	Phone string `json:"phone"`
+	Type  string `json:"type,omitempty"`
+	WaID  string `json:"wa_id,omitempty"`
+}
+
+type ContactEmail struct {
+	Email string `json:"email"`
+	Type  string `json:"type,omitempty"`
+}
+
+func (th *OutboundMedia) Validate() error {
+	switch th.Type {
+	case OUTBOUND_MEDIA_IMAGE, OUTBOUND_MEDIA_VIDEO, OUTBOUND_MEDIA_DOCUMENT:
+	case OUTBOUND_MEDIA_STICKER, OUTBOUND_MEDIA_AUDIO:
+		if th.Caption != "" {
+			return fmt.Errorf("%s messages do not support captions", th.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported media type %s", th.Type)
+	}
+
+	if th.Link == "" && th.ID == "" {
+		return fmt.Errorf("%s message needs a link or a media ID", th.Type)
+	}
+	if th.Filename != "" && th.Type != OUTBOUND_MEDIA_DOCUMENT {
+		return fmt.Errorf("only documents support a filename")
+	}
+	return nil
+}
+
+func (th *OutboundLocation) Validate() error {
+	if th.Latitude < -90 || th.Latitude > 90 || th.Longitude < -180 || th.Longitude > 180 {
+		return fmt.Errorf("invalid coordinates %f,%f", th.Latitude, th.Longitude)
+	}
+	return nil
+}
+
+func ValidateContacts(contacts []OutboundContact) error {
+	if len(contacts) == 0 {
+		return fmt.Errorf("contacts message needs at least one contact")
+	}
+	for _, contact := range contacts {
+		if contact.FormattedName == "" {
+			return fmt.Errorf("contacts need a formatted name")
+		}
+		if len(contact.Phones) == 0 && len(contact.Emails) == 0 {
+			return fmt.Errorf("contact %s needs a phone or an email", contact.FormattedName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package dto
+
+const (
+	OUTBOUND_PAYLOAD_TEXT     = "text"
+	OUTBOUND_PAYLOAD_AUDIO    = "audio"
+	OUTBOUND_PAYLOAD_MEDIA    = "media"
+	OUTBOUND_PAYLOAD_TEMPLATE = "template"
+	OUTBOUND_PAYLOAD_BUTTONS  = "buttons"
+	OUTBOUND_PAYLOAD_LIST     = "list"
+	OUTBOUND_PAYLOAD_LOCATION = "location"
+	OUTBOUND_PAYLOAD_CONTACTS = "contacts"
+)
+
+// SendResult is what a provider reports back when it accepts an outbound message.
+type SendResult struct {
+	Provider          string `json:"provider"`
+	ProviderMessageID string `json:"provider_message_id"`
+	Status            string `json:"status"`
+}
+
+// MetaSendResponse is the body returned by the Graph API messages endpoint.
+type MetaSendResponse struct {
+	MessagingProduct string `json:"messaging_product"`
+	Contacts         []struct {
+		Input string `json:"input"`
+		WaID  string `json:"wa_id"`
+	} `json:"contacts"`
+	Messages []struct {
+		ID            string `json:"id"`
+		MessageStatus string `json:"message_status"`
+	} `json:"messages"`
+}
+
+// InfobipSendResponse is the body returned by the Infobip WhatsApp send endpoints.
+// The template endpoint accepts messages in bulk and returns one entry per message in Messages.
+type InfobipSendResponse struct {
+	To           string                `json:"to"`
+	MessageCount int                   `json:"messageCount"`
+	MessageID    string                `json:"messageId"`
+	Status       *InfobipReportStatus  `json:"status"`
+	BulkID       string                `json:"bulkId,omitempty"`
+	Messages     []InfobipSendResponse `json:"messages,omitempty"`
+}
@@ -1,8 +1,16 @@
 package dto
 
 type QueryAIResponse struct {
-	Response string   `json:"response"`
-	Sources  []string `json:"sources"`
+	Response string          `json:"response"`
+	Sources  []string        `json:"sources"`
+	Options  []QueryAIOption `json:"options,omitempty"`
+}
+
+// QueryAIOption is a choice offered by the AI, sent to the user as a reply button or list row.
+type QueryAIOption struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
 }
 
 type VoiceQueryAIResponse struct {
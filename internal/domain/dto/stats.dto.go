@@ -0,0 +1,7 @@
+package dto
+
+// Stats are the runtime counters of this instance, reset on every restart.
+type Stats struct {
+	DuplicateInboundMessages int64 `json:"duplicate_inbound_messages"`
+	InfobipAuthFailures      int64 `json:"infobip_auth_failures"`
+}
@@ -0,0 +1,196 @@
+package dto
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TELEGRAM_CONVERSATION_PREFIX keeps Telegram chat IDs apart from phone numbers in the
+// conversation IDs used as UserContext keys.
+const TELEGRAM_CONVERSATION_PREFIX = "telegram:"
+
+// TelegramResponse is the envelope of every Bot API response.
+type TelegramResponse[T any] struct {
+	Ok          bool   `json:"ok"`
+	Result      T      `json:"result"`
+	ErrorCode   int    `json:"error_code,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// TelegramUpdate is an incoming update, posted to the webhook or returned by getUpdates.
+type TelegramUpdate struct {
+	UpdateID int64            `json:"update_id"`
+	Message  *TelegramMessage `json:"message,omitempty"`
+}
+
+type TelegramMessage struct {
+	MessageID int64              `json:"message_id"`
+	Date      int64              `json:"date"`
+	Chat      TelegramChat       `json:"chat"`
+	From      *TelegramUser      `json:"from,omitempty"`
+	Text      string             `json:"text,omitempty"`
+	Caption   string             `json:"caption,omitempty"`
+	Voice     *TelegramFile      `json:"voice,omitempty"`
+	Audio     *TelegramFile      `json:"audio,omitempty"`
+	Video     *TelegramFile      `json:"video,omitempty"`
+	Document  *TelegramFile      `json:"document,omitempty"`
+	Sticker   *TelegramFile      `json:"sticker,omitempty"`
+	Photo     []TelegramFile     `json:"photo,omitempty"`
+	Location  *TelegramLocation  `json:"location,omitempty"`
+	Contact   *TelegramContact   `json:"contact,omitempty"`
+	ReplyTo   *TelegramMessageID `json:"reply_to_message,omitempty"`
+}
+
+type TelegramMessageID struct {
+	MessageID int64 `json:"message_id"`
+}
+
+type TelegramChat struct {
+	ID        int64  `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title,omitempty"`
+	Username  string `json:"username,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+}
+
+type TelegramUser struct {
+	ID        int64  `json:"id"`
+	IsBot     bool   `json:"is_bot"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name,omitempty"`
+	Username  string `json:"username,omitempty"`
+}
+
+// TelegramFile covers the fields shared by voice, audio, video, document, sticker and photo
+// sizes, and the getFile response (FilePath).
+type TelegramFile struct {
+	FileID   string `json:"file_id"`
+	FileSize int64  `json:"file_size,omitempty"`
+	FileName string `json:"file_name,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+	FilePath string `json:"file_path,omitempty"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Animated bool   `json:"is_animated,omitempty"`
+}
+
+type TelegramLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type TelegramContact struct {
+	PhoneNumber string `json:"phone_number"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name,omitempty"`
+}
+
+// TelegramSendRequest is the body of the sendMessage, sendVoice, sendAudio, sendPhoto,
+// sendVideo, sendDocument, sendSticker and sendLocation methods. Only the field of the
+// called method is set.
+type TelegramSendRequest struct {
+	ChatID    string   `json:"chat_id"`
+	Text      string   `json:"text,omitempty"`
+	Caption   string   `json:"caption,omitempty"`
+	Voice     string   `json:"voice,omitempty"`
+	Audio     string   `json:"audio,omitempty"`
+	Photo     string   `json:"photo,omitempty"`
+	Video     string   `json:"video,omitempty"`
+	Document  string   `json:"document,omitempty"`
+	Sticker   string   `json:"sticker,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// TelegramWebhookRequest is the body of setWebhook.
+type TelegramWebhookRequest struct {
+	URL            string   `json:"url"`
+	SecretToken    string   `json:"secret_token,omitempty"`
+	AllowedUpdates []string `json:"allowed_updates"`
+}
+
+// TelegramConversationID returns the conversation ID of a Telegram chat.
+func TelegramConversationID(chatID int64) string {
+	return TELEGRAM_CONVERSATION_PREFIX + strconv.FormatInt(chatID, 10)
+}
+
+// ToInboundMessage maps a Telegram update into the normalized inbound model. Updates
+// without a message (edits, channel posts, ...) are skipped.
+//
+// Returns:
+//   - InboundMessage: The normalized message.
+//   - bool: False when the update carries no message to process.
+func (th *TelegramUpdate) ToInboundMessage() (InboundMessage, bool) {
+	message := th.Message
+	if message == nil {
+		return InboundMessage{}, false
+	}
+
+	chatID := strconv.FormatInt(message.Chat.ID, 10)
+	contactName := strings.TrimSpace(message.Chat.FirstName + " " + message.Chat.LastName)
+	if message.From != nil {
+		contactName = strings.TrimSpace(message.From.FirstName + " " + message.From.LastName)
+	}
+
+	inbound := InboundMessage{
+		// Message IDs are only unique within a chat.
+		ID:             fmt.Sprintf("%s:%d", chatID, message.MessageID),
+		Provider:       PROVIDER_TELEGRAM,
+		ConversationID: TelegramConversationID(message.Chat.ID),
+		From:           chatID,
+		ReplyTo:        chatID,
+		ContactName:    contactName,
+		Type:           INBOUND_MESSAGE_TEXT,
+		Text:           message.Text,
+		ReceivedAt:     time.Unix(message.Date, 0).UTC(),
+	}
+	if message.ReplyTo != nil {
+		inbound.ContextMessageID = fmt.Sprintf("%s:%d", chatID, message.ReplyTo.MessageID)
+	}
+
+	media := func(messageType string, file *TelegramFile, voice bool) {
+		inbound.Type = messageType
+		inbound.Text = message.Caption
+		inbound.Media = &InboundMedia{
+			ID:       file.FileID,
+			MimeType: file.MimeType,
+			Caption:  message.Caption,
+			Filename: file.FileName,
+			Voice:    voice,
+			Animated: file.Animated,
+		}
+	}
+
+	switch {
+	case message.Text != "":
+	case message.Voice != nil:
+		media(INBOUND_MESSAGE_AUDIO, message.Voice, true)
+	case message.Audio != nil:
+		media(INBOUND_MESSAGE_AUDIO, message.Audio, false)
+	case len(message.Photo) > 0:
+		// Telegram sends every available size, the last one is the largest.
+		media(INBOUND_MESSAGE_IMAGE, &message.Photo[len(message.Photo)-1], false)
+	case message.Video != nil:
+		media(INBOUND_MESSAGE_VIDEO, message.Video, false)
+	case message.Document != nil:
+		media(INBOUND_MESSAGE_DOCUMENT, message.Document, false)
+	case message.Sticker != nil:
+		media(INBOUND_MESSAGE_STICKER, message.Sticker, false)
+	case message.Location != nil:
+		inbound.Type = INBOUND_MESSAGE_LOCATION
+		inbound.Location = &InboundLocation{Latitude: message.Location.Latitude, Longitude: message.Location.Longitude}
+	case message.Contact != nil:
+		inbound.Type = INBOUND_MESSAGE_CONTACTS
+		inbound.Contacts = []InboundContact{{
+			Name:   strings.TrimSpace(message.Contact.FirstName + " " + message.Contact.LastName),
+			Phones: []string{message.Contact.PhoneNumber},
+		}}
+	default:
+		inbound.Type = INBOUND_MESSAGE_UNSUPPORTED
+	}
+
+	return inbound, true
+}
@@ -0,0 +1,49 @@
+package dto
+
+const (
+	TEMPLATE_HEADER_TEXT     = "text"
+	TEMPLATE_HEADER_IMAGE    = "image"
+	TEMPLATE_HEADER_VIDEO    = "video"
+	TEMPLATE_HEADER_DOCUMENT = "document"
+
+	TEMPLATE_BUTTON_QUICK_REPLY = "quick_reply"
+	TEMPLATE_BUTTON_URL         = "url"
+)
+
+// TemplateMessage is the provider agnostic representation of a WhatsApp template (HSM)
+// with the values of its placeholders.
+type TemplateMessage struct {
+	Name     string                    `json:"name"`
+	Language string                    `json:"language"`
+	Header   *TemplateHeaderParameter  `json:"header,omitempty"`
+	Body     []string                  `json:"body,omitempty"`
+	Buttons  []TemplateButtonParameter `json:"buttons,omitempty"`
+}
+
+// TemplateHeaderParameter fills the header of a template: a text placeholder or a media link.
+type TemplateHeaderParameter struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	MediaURL string `json:"media_url,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// TemplateButtonParameter fills the dynamic part of the button at Index: the payload of a
+// quick reply or the URL suffix of a URL button.
+type TemplateButtonParameter struct {
+	Type      string `json:"type"`
+	Index     int    `json:"index"`
+	Parameter string `json:"parameter"`
+}
+
+// SendTemplateRequest is the body of the template sending endpoint.
+type SendTemplateRequest struct {
+	Provider       string                    `json:"provider"`
+	To             string                    `json:"to"`
+	ConversationID string                    `json:"conversation_id"`
+	Template       string                    `json:"template"`
+	Language       string                    `json:"language"`
+	Header         *TemplateHeaderParameter  `json:"header,omitempty"`
+	Body           []string                  `json:"body,omitempty"`
+	Buttons        []TemplateButtonParameter `json:"buttons,omitempty"`
+}
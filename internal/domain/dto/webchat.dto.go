@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// Events pushed to web chat visitors over the WebSocket or the SSE stream.
+const (
+	WEBCHAT_EVENT_MESSAGE = "message"
+	WEBCHAT_EVENT_ERROR   = "error"
+)
+
+// WebChatSession is an anonymous visitor session. The token authenticates every later
+// request of the visitor and carries its own expiry.
+type WebChatSession struct {
+	SessionID string    `json:"session_id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// WebChatInbound is a message typed by the visitor. ReplyID is set when the visitor picks
+// one of the options of an agent message; ID lets the widget retry without duplicates.
+type WebChatInbound struct {
+	ID      string `json:"id,omitempty"`
+	Text    string `json:"text"`
+	ReplyID string `json:"reply_id,omitempty"`
+}
+
+// WebChatEvent is what the widget receives.
+type WebChatEvent struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id,omitempty"`
+	Message *ChannelMessage `json:"message,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	SentAt  time.Time       `json:"sent_at"`
+}
+
+// WebChatConversationID returns the conversation ID of a web chat session.
+func WebChatConversationID(sessionID string) string {
+	return PROVIDER_WEBCHAT + ":" + sessionID
+}
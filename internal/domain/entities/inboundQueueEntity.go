@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"social-connector/internal/domain/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	QUEUE_STATUS_PENDING    = "pending"
+	QUEUE_STATUS_PROCESSING = "processing"
+)
+
+type InboundQueueItem struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ConversationID string             `json:"conversation_id" bson:"conversation_id"`
+	Message        dto.InboundMessage `json:"message" bson:"message"`
+	Status         string             `json:"status" bson:"status"`
+	Attempts       int                `json:"attempts" bson:"attempts"`
+	LastError      string             `json:"last_error" bson:"last_error,omitempty"`
+	AvailableAt    time.Time          `json:"available_at" bson:"available_at"`
+	LeaseOwner     string             `json:"lease_owner" bson:"lease_owner,omitempty"`
+	LeaseUntil     time.Time          `json:"lease_until" bson:"lease_until,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type DeadLetterItem struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ConversationID string             `json:"conversation_id" bson:"conversation_id"`
+	Message        dto.InboundMessage `json:"message" bson:"message"`
+	Attempts       int                `json:"attempts" bson:"attempts"`
+	LastError      string             `json:"last_error" bson:"last_error"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	FailedAt       time.Time          `json:"failed_at" bson:"failed_at"`
+}
+
+// ConversationLock guarantees a single worker, across every running instance,
+// processes messages of a conversation at any given time.
+type ConversationLock struct {
+	ConversationID string    `json:"conversation_id" bson:"_id"`
+	Owner          string    `json:"owner" bson:"owner"`
+	LeaseUntil     time.Time `json:"lease_until" bson:"lease_until"`
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StoredMedia is an inbound media file downloaded from a provider and kept in the blob store under Key.
+type StoredMedia struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Key             string             `json:"key" bson:"key"`
+	Provider        string             `json:"provider" bson:"provider"`
+	ProviderMediaID string             `json:"provider_media_id" bson:"provider_media_id"`
+	MessageID       string             `json:"message_id" bson:"message_id"`
+	ConversationID  string             `json:"conversation_id" bson:"conversation_id"`
+	Type            string             `json:"type" bson:"type"`
+	MimeType        string             `json:"mime_type" bson:"mime_type"`
+	Filename        string             `json:"filename,omitempty" bson:"filename,omitempty"`
+	Size            int64              `json:"size" bson:"size"`
+	SHA256          string             `json:"sha256" bson:"sha256"`
+	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// MediaUpload caches the media ID returned by a provider for an uploaded file, keyed by the
+// phone number it was uploaded for and the SHA-256 of the content, or the link and ETag it
+// was downloaded from. The document expires
+// through a TTL index on ExpiresAt, before the provider forgets the media.
+type MediaUpload struct {
+	ID        string    `json:"id" bson:"_id"`
+	Provider  string    `json:"provider" bson:"provider"`
+	MediaID   string    `json:"media_id" bson:"media_id"`
+	SHA256    string    `json:"sha256" bson:"sha256"`
+	MimeType  string    `json:"mime_type" bson:"mime_type"`
+	Size      int64     `json:"size" bson:"size"`
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,29 @@
+package entities
+
+// MessageTemplate is an entry of the local template registry. It mirrors a template approved
+// in the WhatsApp Business account, with one variant per language.
+type MessageTemplate struct {
+	Name            string                     `json:"name" yaml:"name"`
+	Category        string                     `json:"category" yaml:"category"`
+	DefaultLanguage string                     `json:"default_language" yaml:"default_language"`
+	Languages       map[string]TemplateVariant `json:"languages" yaml:"languages"`
+}
+
+// TemplateVariant is the content of a template in one language. Placeholders use the
+// WhatsApp {{1}}, {{2}}... notation.
+type TemplateVariant struct {
+	Header  *TemplateHeader  `json:"header,omitempty" yaml:"header"`
+	Body    string           `json:"body" yaml:"body"`
+	Buttons []TemplateButton `json:"buttons,omitempty" yaml:"buttons"`
+}
+
+type TemplateHeader struct {
+	Format string `json:"format" yaml:"format"`
+	Text   string `json:"text,omitempty" yaml:"text"`
+}
+
+type TemplateButton struct {
+	Type string `json:"type" yaml:"type"`
+	Text string `json:"text" yaml:"text"`
+	URL  string `json:"url,omitempty" yaml:"url"`
+}
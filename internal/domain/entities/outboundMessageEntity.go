@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"social-connector/internal/domain/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboundMessage is the record of a message sent by the connector, keyed by the
+// provider message ID, with the history of every status reported for it. Pricing
+// holds the cost reported by the provider. ProviderIDMissing marks messages the provider
+// accepted without returning an ID, stored under a generated one.
+type OutboundMessage struct {
+	ID                primitive.ObjectID             `json:"id" bson:"_id,omitempty"`
+	Provider          string                         `json:"provider" bson:"provider"`
+	ProviderMessageID string                         `json:"provider_message_id" bson:"provider_message_id"`
+	ProviderIDMissing bool                           `json:"provider_id_missing,omitempty" bson:"provider_id_missing,omitempty"`
+	ConversationID    string                         `json:"conversation_id" bson:"conversation_id"`
+	Recipient         string                         `json:"recipient" bson:"recipient"`
+	PayloadType       string                         `json:"payload_type,omitempty" bson:"payload_type,omitempty"`
+	Content           string                         `json:"content,omitempty" bson:"content,omitempty"`
+	Status            string                         `json:"status" bson:"status"`
+	StatusHistory     []OutboundStatus               `json:"status_history" bson:"status_history"`
+	Errors            []dto.MessageStatusError       `json:"errors,omitempty" bson:"errors,omitempty"`
+	Pricing           *dto.MessageStatusPricing      `json:"pricing,omitempty" bson:"pricing,omitempty"`
+	Conversation      *dto.MessageStatusConversation `json:"conversation,omitempty" bson:"conversation,omitempty"`
+	SentAt            *time.Time                     `json:"sent_at,omitempty" bson:"sent_at,omitempty"`
+	CreatedAt         time.Time                      `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time                      `json:"updated_at" bson:"updated_at"`
+}
+
+type OutboundStatus struct {
+	Status    string                   `json:"status" bson:"status"`
+	Timestamp time.Time                `json:"timestamp" bson:"timestamp"`
+	Errors    []dto.MessageStatusError `json:"errors,omitempty" bson:"errors,omitempty"`
+}
@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// ProcessedMessage marks an inbound provider message as already accepted.
+// The document expires through a TTL index on CreatedAt.
+type ProcessedMessage struct {
+	ID             string    `json:"id" bson:"_id"`
+	Provider       string    `json:"provider" bson:"provider"`
+	MessageID      string    `json:"message_id" bson:"message_id"`
+	ConversationID string    `json:"conversation_id" bson:"conversation_id"`
+	CreatedAt      time.Time `json:"created_at" bson:"created_at"`
+}
@@ -13,5 +13,6 @@ type Transcript struct {
 	Role      string    `json:"role" bson:"role"`
 	Message   string    `json:"message" bson:"message"`
 	Audio     string    `json:"audio" bson:"audio,omitempty"`
+	MediaID   string    `json:"media_id,omitempty" bson:"media_id,omitempty"`
 	Timestamp time.Time `json:"timestamp" bson:"timestamp"`
 }
@@ -1,3 +1,10 @@
 package repocontants
 
 var USER_CONTEXT_COLLECTION = "userContext"
+var INBOUND_QUEUE_COLLECTION = "inboundQueue"
+var INBOUND_DEAD_LETTER_COLLECTION = "inboundDeadLetter"
+var CONVERSATION_LOCK_COLLECTION = "conversationLocks"
+var PROCESSED_MESSAGE_COLLECTION = "processedMessages"
+var OUTBOUND_MESSAGE_COLLECTION = "outboundMessages"
+var MEDIA_COLLECTION = "media"
+var MEDIA_UPLOAD_COLLECTION = "mediaUploads"
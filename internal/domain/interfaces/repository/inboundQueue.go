@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"social-connector/internal/domain/entities"
+	"time"
+)
+
+type InboundQueueRepository interface {
+	EnsureIndexes(ctx context.Context) error
+	Enqueue(ctx context.Context, items []entities.InboundQueueItem) error
+	ClaimNext(ctx context.Context, owner string, lease time.Duration) (*entities.InboundQueueItem, error)
+	ExtendLease(ctx context.Context, item entities.InboundQueueItem, owner string, lease time.Duration) error
+	Complete(ctx context.Context, item entities.InboundQueueItem, owner string) error
+	Retry(ctx context.Context, item entities.InboundQueueItem, owner string, availableAt time.Time, lastError string) error
+	DeadLetter(ctx context.Context, item entities.InboundQueueItem, owner string, lastError string) error
+	RequeueExpired(ctx context.Context) (int64, error)
+}
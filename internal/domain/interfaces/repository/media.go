@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"social-connector/internal/domain/entities"
+)
+
+type MediaRepository interface {
+	EnsureIndexes(ctx context.Context) error
+	Create(ctx context.Context, media entities.StoredMedia) (entities.StoredMedia, error)
+	FindByID(ctx context.Context, id string) (entities.StoredMedia, error)
+	FindByProviderMediaID(ctx context.Context, provider string, providerMediaID string) (entities.StoredMedia, error)
+}
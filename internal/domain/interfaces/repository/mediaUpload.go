@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"social-connector/internal/domain/entities"
+)
+
+type MediaUploadRepository interface {
+	EnsureIndexes(ctx context.Context) error
+	Find(ctx context.Context, id string) (entities.MediaUpload, error)
+	Save(ctx context.Context, upload entities.MediaUpload) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+)
+
+type OutboundMessageRepository interface {
+	EnsureIndexes(ctx context.Context) error
+	SaveSent(ctx context.Context, message entities.OutboundMessage) (entities.OutboundMessage, error)
+	AppendStatus(ctx context.Context, event dto.MessageStatusEvent, advance bool) (entities.OutboundMessage, error)
+	FindByProviderMessageID(ctx context.Context, provider string, providerMessageID string) (entities.OutboundMessage, error)
+	FindByConversationID(ctx context.Context, conversationID string) ([]entities.OutboundMessage, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"social-connector/internal/domain/entities"
+	"time"
+)
+
+type ProcessedMessageRepository interface {
+	EnsureIndexes(ctx context.Context, ttl time.Duration) error
+	Register(ctx context.Context, entity entities.ProcessedMessage) (bool, error)
+	Remove(ctx context.Context, id string) error
+}
@@ -3,5 +3,5 @@ package Iservices
 import "social-connector/internal/domain/dto"
 
 type IChannelServices interface {
-	WebhookService(webhookDto *dto.InboundResponse)
+	ProcessInbound(message dto.InboundMessage) error
 }
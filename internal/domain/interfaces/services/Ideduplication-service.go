@@ -0,0 +1,9 @@
+package Iservices
+
+import "social-connector/internal/domain/dto"
+
+type IDeduplicationService interface {
+	Filter(messages []dto.InboundMessage) ([]dto.InboundMessage, error)
+	Forget(messages []dto.InboundMessage)
+	DuplicateCount() int64
+}
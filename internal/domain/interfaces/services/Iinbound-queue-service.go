@@ -0,0 +1,7 @@
+package Iservices
+
+import "social-connector/internal/domain/dto"
+
+type IInboundQueueService interface {
+	Enqueue(messages []dto.InboundMessage) error
+}
@@ -0,0 +1,16 @@
+package Iservices
+
+import (
+	"io"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+)
+
+type IMediaService interface {
+	StoreInbound(message dto.InboundMessage) (entities.StoredMedia, error)
+	StoreRemote(link string, conversationID string, mediaType string) (entities.StoredMedia, error)
+	FindMedia(id string) (entities.StoredMedia, error)
+	Open(media entities.StoredMedia) (io.ReadSeekCloser, error)
+	URL(media entities.StoredMedia) string
+	VerifySignature(id string, expires string, signature string) bool
+}
@@ -0,0 +1,13 @@
+package Iservices
+
+import (
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+)
+
+type IMessageStatusService interface {
+	RecordSent(conversationID string, recipient string, payloadType string, content string, result dto.SendResult) (entities.OutboundMessage, error)
+	RecordStatus(event dto.MessageStatusEvent) (entities.OutboundMessage, error)
+	FindMessage(provider string, providerMessageID string) (entities.OutboundMessage, error)
+	FindConversationMessages(conversationID string) ([]entities.OutboundMessage, error)
+}
@@ -0,0 +1,20 @@
+package Iservices
+
+import (
+	"errors"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+)
+
+var (
+	// ErrInvalidTemplateRequest wraps the errors of a request that cannot be sent as is.
+	ErrInvalidTemplateRequest = errors.New("invalid template request")
+	// ErrTemplateNotSent wraps the errors of a provider that rejected or could not be reached to send a template.
+	ErrTemplateNotSent = errors.New("template not sent")
+)
+
+type ITemplateService interface {
+	ListTemplates() []entities.MessageTemplate
+	FindTemplate(name string) (entities.MessageTemplate, bool)
+	SendTemplate(request dto.SendTemplateRequest) (entities.OutboundMessage, error)
+}
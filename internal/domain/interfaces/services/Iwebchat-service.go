@@ -0,0 +1,14 @@
+package Iservices
+
+import (
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+)
+
+type IWebChatService interface {
+	CreateSession() (dto.WebChatSession, error)
+	VerifyToken(token string) (string, error)
+	ReceiveMessage(sessionID string, message dto.WebChatInbound) error
+	Transcript(sessionID string) ([]entities.Transcript, error)
+	Subscribe(sessionID string) (<-chan dto.WebChatEvent, func())
+}
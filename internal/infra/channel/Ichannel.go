@@ -0,0 +1,12 @@
+package channel
+
+import "social-connector/internal/domain/dto"
+
+// IChannel is a messenger the connector can talk through. Each implementation maps the
+// channel agnostic dto.ChannelMessage into its own API.
+type IChannel interface {
+	Name() string
+	Capabilities() dto.ChannelCapabilities
+	Send(to string, message dto.ChannelMessage) (dto.SendResult, error)
+	DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error)
+}
@@ -0,0 +1,87 @@
+package channel
+
+import (
+	"fmt"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/infra/provider"
+	"unicode/utf8"
+)
+
+// MessengerCapabilities is what Facebook Messenger and Instagram Direct can deliver. Reply
+// buttons and list rows are sent as quick replies.
+var MessengerCapabilities = dto.ChannelCapabilities{
+	Text:    true,
+	Audio:   true,
+	Media:   true,
+	Buttons: true,
+	List:    true,
+}
+
+// MessengerChannel exposes a Messenger page or an Instagram account as a channel. Recipients
+// are page-scoped or Instagram-scoped IDs.
+type MessengerChannel struct {
+	Provider *provider.MetaMessengerProvider
+}
+
+func NewMessengerChannel(messengerProvider *provider.MetaMessengerProvider) *MessengerChannel {
+	return &MessengerChannel{Provider: messengerProvider}
+}
+
+func (th *MessengerChannel) Name() string {
+	return th.Provider.Provider
+}
+
+func (th *MessengerChannel) Capabilities() dto.ChannelCapabilities {
+	return MessengerCapabilities
+}
+
+// Send delivers a channel message through the Send API.
+func (th *MessengerChannel) Send(to string, message dto.ChannelMessage) (dto.SendResult, error) {
+	if err := message.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	switch message.Type {
+	case dto.OUTBOUND_PAYLOAD_TEXT:
+		return th.Provider.SendTextMessage(to, message.Text, nil)
+	case dto.OUTBOUND_PAYLOAD_AUDIO, dto.OUTBOUND_PAYLOAD_MEDIA:
+		return th.Provider.SendAttachmentMessage(to, *message.Media)
+	case dto.OUTBOUND_PAYLOAD_BUTTONS:
+		if err := message.Buttons.Validate(); err != nil {
+			return dto.SendResult{}, err
+		}
+		quickReplies := []dto.MessengerQuickReply{}
+		for _, button := range message.Buttons.Buttons {
+			quickReplies = append(quickReplies, quickReply(button.ID, button.Title))
+		}
+		return th.Provider.SendTextMessage(to, message.Buttons.Body, quickReplies)
+	case dto.OUTBOUND_PAYLOAD_LIST:
+		if err := message.List.Validate(); err != nil {
+			return dto.SendResult{}, err
+		}
+		quickReplies := []dto.MessengerQuickReply{}
+		for _, section := range message.List.Sections {
+			for _, row := range section.Rows {
+				quickReplies = append(quickReplies, quickReply(row.ID, row.Title))
+			}
+		}
+		if len(quickReplies) > dto.MAX_QUICK_REPLIES {
+			quickReplies = quickReplies[:dto.MAX_QUICK_REPLIES]
+		}
+		return th.Provider.SendTextMessage(to, message.List.Body, quickReplies)
+	default:
+		return dto.SendResult{}, fmt.Errorf("channel %s does not support %s messages", th.Name(), message.Type)
+	}
+}
+
+func (th *MessengerChannel) DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error) {
+	return th.Provider.DownloadMedia(media)
+}
+
+// quickReply builds a text quick reply, shortening the title to the Send API limit.
+func quickReply(payload string, title string) dto.MessengerQuickReply {
+	if utf8.RuneCountInString(title) > dto.MAX_QUICK_REPLY_TITLE {
+		title = string([]rune(title)[:dto.MAX_QUICK_REPLY_TITLE])
+	}
+	return dto.MessengerQuickReply{ContentType: "text", Title: title, Payload: payload}
+}
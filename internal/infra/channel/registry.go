@@ -0,0 +1,50 @@
+package channel
+
+import (
+	"fmt"
+	"social-connector/internal/domain/dto"
+	"sort"
+)
+
+// Registry resolves the channel a conversation has to be answered through from its origin,
+// the provider name its inbound messages arrive with (dto.PROVIDER_*).
+type Registry struct {
+	Channels map[string]IChannel
+}
+
+func NewRegistry(channels ...IChannel) *Registry {
+	registry := &Registry{Channels: map[string]IChannel{}}
+	for _, channel := range channels {
+		registry.Register(channel)
+	}
+	return registry
+}
+
+// Register adds a channel, replacing any channel registered under the same name.
+func (th *Registry) Register(channel IChannel) {
+	th.Channels[channel.Name()] = channel
+}
+
+// Resolve returns the channel registered for the origin.
+func (th *Registry) Resolve(origin string) (IChannel, error) {
+	channel, ok := th.Channels[origin]
+	if !ok {
+		return nil, fmt.Errorf("no channel registered for %s", origin)
+	}
+	return channel, nil
+}
+
+// ResolveMessage returns the channel an inbound message has to be answered through.
+func (th *Registry) ResolveMessage(message dto.InboundMessage) (IChannel, error) {
+	return th.Resolve(message.Provider)
+}
+
+// Names returns the registered channel names in alphabetical order.
+func (th *Registry) Names() []string {
+	names := make([]string, 0, len(th.Channels))
+	for name := range th.Channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
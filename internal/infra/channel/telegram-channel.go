@@ -0,0 +1,60 @@
+package channel
+
+import (
+	"fmt"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/infra/provider"
+)
+
+// TelegramCapabilities is what the Telegram channel can deliver. Options offered by the AI
+// are sent as text, and templates do not exist on Telegram.
+var TelegramCapabilities = dto.ChannelCapabilities{
+	Text:     true,
+	Audio:    true,
+	Media:    true,
+	Location: true,
+}
+
+// TelegramChannel exposes a Telegram bot as a channel. Recipients are chat IDs.
+type TelegramChannel struct {
+	Provider *provider.TelegramProvider
+}
+
+func NewTelegramChannel(telegramProvider *provider.TelegramProvider) *TelegramChannel {
+	return &TelegramChannel{Provider: telegramProvider}
+}
+
+func (th *TelegramChannel) Name() string {
+	return dto.PROVIDER_TELEGRAM
+}
+
+func (th *TelegramChannel) Capabilities() dto.ChannelCapabilities {
+	return TelegramCapabilities
+}
+
+// Send delivers a channel message to a Telegram chat. Audio sent by link goes out as a voice note.
+func (th *TelegramChannel) Send(to string, message dto.ChannelMessage) (dto.SendResult, error) {
+	if err := message.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	switch message.Type {
+	case dto.OUTBOUND_PAYLOAD_TEXT:
+		return th.Provider.SendTextMessage(to, message.Text)
+	case dto.OUTBOUND_PAYLOAD_AUDIO:
+		if message.Media.Link == "" {
+			return th.Provider.SendMediaMessage(to, *message.Media)
+		}
+		return th.Provider.SendVoiceMessage(to, message.Media.Link)
+	case dto.OUTBOUND_PAYLOAD_MEDIA:
+		return th.Provider.SendMediaMessage(to, *message.Media)
+	case dto.OUTBOUND_PAYLOAD_LOCATION:
+		return th.Provider.SendLocationMessage(to, *message.Location)
+	default:
+		return dto.SendResult{}, fmt.Errorf("channel %s does not support %s messages", dto.PROVIDER_TELEGRAM, message.Type)
+	}
+}
+
+func (th *TelegramChannel) DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error) {
+	return th.Provider.DownloadMedia(media)
+}
@@ -0,0 +1,59 @@
+package channel
+
+import (
+	"fmt"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/infra/webchat"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebChatCapabilities is what the embeddable widget can render.
+var WebChatCapabilities = dto.ChannelCapabilities{
+	Text:    true,
+	Audio:   true,
+	Media:   true,
+	Buttons: true,
+	List:    true,
+}
+
+// WebChatChannel delivers agent replies to the website widget. Recipients are web chat session IDs.
+type WebChatChannel struct {
+	Hub *webchat.Hub
+}
+
+func NewWebChatChannel(hub *webchat.Hub) *WebChatChannel {
+	return &WebChatChannel{Hub: hub}
+}
+
+func (th *WebChatChannel) Name() string {
+	return dto.PROVIDER_WEBCHAT
+}
+
+func (th *WebChatChannel) Capabilities() dto.ChannelCapabilities {
+	return WebChatCapabilities
+}
+
+// Send pushes a message to the open connections of the session. The status is delivered when
+// a connection received it and sent when it is queued until the visitor reconnects.
+func (th *WebChatChannel) Send(to string, message dto.ChannelMessage) (dto.SendResult, error) {
+	if err := message.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+	if !WebChatCapabilities.Supports(message.Type) {
+		return dto.SendResult{}, fmt.Errorf("channel %s does not support %s messages", dto.PROVIDER_WEBCHAT, message.Type)
+	}
+
+	result := dto.SendResult{Provider: dto.PROVIDER_WEBCHAT, ProviderMessageID: primitive.NewObjectID().Hex(), Status: dto.MESSAGE_STATUS_SENT}
+	event := dto.WebChatEvent{Type: dto.WEBCHAT_EVENT_MESSAGE, ID: result.ProviderMessageID, Message: &message, SentAt: time.Now()}
+	if th.Hub.Publish(to, event) {
+		result.Status = dto.MESSAGE_STATUS_DELIVERED
+	}
+	return result, nil
+}
+
+// DownloadMedia is not supported: visitors can only type text.
+func (th *WebChatChannel) DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error) {
+	return dto.DownloadedMedia{}, fmt.Errorf("channel %s does not receive media", dto.PROVIDER_WEBCHAT)
+}
@@ -0,0 +1,89 @@
+package channel
+
+import (
+	"fmt"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/infra/provider"
+)
+
+// WhatsAppCapabilities is everything the WhatsApp Business API can deliver.
+var WhatsAppCapabilities = dto.ChannelCapabilities{
+	Text:      true,
+	Audio:     true,
+	Media:     true,
+	Location:  true,
+	Contacts:  true,
+	Buttons:   true,
+	List:      true,
+	Templates: true,
+}
+
+// WhatsAppChannel exposes a WhatsApp provider (Infobip or Meta Cloud API) as a channel.
+type WhatsAppChannel struct {
+	ChannelName         string
+	Provider            provider.IWhatsAppProvider
+	ChannelCapabilities dto.ChannelCapabilities
+}
+
+// NewInfobipWhatsAppChannel exposes WhatsApp through Infobip as the infobip channel.
+func NewInfobipWhatsAppChannel(infobipProvider *provider.InfobipWhatsAppProvider) *WhatsAppChannel {
+	return &WhatsAppChannel{ChannelName: dto.PROVIDER_INFOBIP, Provider: infobipProvider, ChannelCapabilities: WhatsAppCapabilities}
+}
+
+// NewMetaWhatsAppChannel exposes WhatsApp through the Meta Cloud API as the meta channel.
+func NewMetaWhatsAppChannel(metaProvider *provider.MetaWhatsAppProvider) *WhatsAppChannel {
+	return &WhatsAppChannel{ChannelName: dto.PROVIDER_META, Provider: metaProvider, ChannelCapabilities: WhatsAppCapabilities}
+}
+
+func (th *WhatsAppChannel) Name() string {
+	return th.ChannelName
+}
+
+func (th *WhatsAppChannel) Capabilities() dto.ChannelCapabilities {
+	return th.ChannelCapabilities
+}
+
+// Send delivers a channel message through the matching WhatsApp provider call.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in the format the provider expects.
+//   - message: dto.ChannelMessage - The message to send.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by the provider.
+//   - error: Returns an error if the message is invalid, its type is not supported by the
+//     channel or the provider rejects it.
+func (th *WhatsAppChannel) Send(to string, message dto.ChannelMessage) (dto.SendResult, error) {
+	if err := message.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+	if !th.ChannelCapabilities.Supports(message.Type) {
+		return dto.SendResult{}, fmt.Errorf("channel %s does not support %s messages", th.ChannelName, message.Type)
+	}
+
+	switch message.Type {
+	case dto.OUTBOUND_PAYLOAD_TEXT:
+		return th.Provider.SendTextMessage(to, message.Text)
+	case dto.OUTBOUND_PAYLOAD_AUDIO:
+		if message.Media.Link == "" {
+			return th.Provider.SendMediaMessage(to, *message.Media)
+		}
+		return th.Provider.SendAudioMessage(to, message.Media.Link)
+	case dto.OUTBOUND_PAYLOAD_MEDIA:
+		return th.Provider.SendMediaMessage(to, *message.Media)
+	case dto.OUTBOUND_PAYLOAD_LOCATION:
+		return th.Provider.SendLocationMessage(to, *message.Location)
+	case dto.OUTBOUND_PAYLOAD_CONTACTS:
+		return th.Provider.SendContactsMessage(to, message.Contacts)
+	case dto.OUTBOUND_PAYLOAD_TEMPLATE:
+		return th.Provider.SendTemplateMessage(to, *message.Template)
+	case dto.OUTBOUND_PAYLOAD_BUTTONS:
+		return th.Provider.SendButtonsMessage(to, *message.Buttons)
+	default:
+		return th.Provider.SendListMessage(to, *message.List)
+	}
+}
+
+func (th *WhatsAppChannel) DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error) {
+	return th.Provider.DownloadMedia(media)
+}
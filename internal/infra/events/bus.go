@@ -0,0 +1,54 @@
+package events
+
+import (
+	"fmt"
+	"social-connector/internal/infra/logger"
+	"sync"
+)
+
+const (
+	MESSAGE_STATUS_UPDATED = "message.status.updated"
+	MESSAGE_FAILED         = "message.failed"
+)
+
+type Handler func(payload interface{})
+
+// Bus is an in-process publish/subscribe event bus. Handlers run asynchronously,
+// one goroutine per delivery, so a slow or panicking subscriber never blocks the publisher.
+type Bus struct {
+	Logger *logger.Logger
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus(logger *logger.Logger) *Bus {
+	return &Bus{Logger: logger, handlers: map[string][]Handler{}}
+}
+
+// Subscribe registers handler to be called for every event published under name.
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish delivers payload to every handler subscribed to name.
+func (b *Bus) Publish(name string, payload interface{}) {
+	b.mu.RLock()
+	handlers := b.handlers[name]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		go func(handler Handler) {
+			defer func() {
+				if r := recover(); r != nil {
+					b.Logger.Error(fmt.Sprintf("Recovered from panic in %s event handler: %v", name, r))
+				}
+			}()
+
+			handler(payload)
+		}(handler)
+	}
+}
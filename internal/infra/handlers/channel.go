@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/infra/channel"
+	"social-connector/internal/infra/logger"
+)
+
+type ChannelHandlers struct {
+	Logger   *logger.Logger
+	Channels *channel.Registry
+}
+
+func NewChannelHandlers(logger *logger.Logger, channels *channel.Registry) *ChannelHandlers {
+	return &ChannelHandlers{Logger: logger, Channels: channels}
+}
+
+// ListChannels returns the registered channels with the kinds of message each one can send.
+func (th *ChannelHandlers) ListChannels(w http.ResponseWriter, r *http.Request) {
+	channels := []dto.ChannelInfo{}
+	for _, name := range th.Channels.Names() {
+		registered, _ := th.Channels.Resolve(name)
+		channels = append(channels, dto.ChannelInfo{Name: name, Capabilities: registered.Capabilities()})
+	}
+
+	writeJSON(w, http.StatusOK, channels)
+}
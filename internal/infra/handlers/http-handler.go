@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"social-connector/internal/domain/dto"
 	Iservices "social-connector/internal/domain/interfaces/services"
@@ -9,12 +10,13 @@ import (
 )
 
 type InfobipHandlers struct {
-	Logger         *logger.Logger
-	ChannelService Iservices.IChannelServices
+	Logger               *logger.Logger
+	InboundQueueService  Iservices.IInboundQueueService
+	MessageStatusService Iservices.IMessageStatusService
 }
 
-func NewInfobipHandlers(logger *logger.Logger, channelService Iservices.IChannelServices) *InfobipHandlers {
-	return &InfobipHandlers{Logger: logger, ChannelService: channelService}
+func NewInfobipHandlers(logger *logger.Logger, inboundQueueService Iservices.IInboundQueueService, messageStatusService Iservices.IMessageStatusService) *InfobipHandlers {
+	return &InfobipHandlers{Logger: logger, InboundQueueService: inboundQueueService, MessageStatusService: messageStatusService}
 }
 
 func (th *InfobipHandlers) InfoBipWebhook(w http.ResponseWriter, r *http.Request) {
@@ -23,7 +25,7 @@ func (th *InfobipHandlers) InfoBipWebhook(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	var webhookRequest *dto.InboundResponse
+	var webhookRequest dto.InboundResponse
 	err := json.NewDecoder(r.Body).Decode(&webhookRequest)
 	if err != nil {
 		http.Error(w, "Error to process JSON", http.StatusBadRequest)
@@ -31,7 +33,57 @@ func (th *InfobipHandlers) InfoBipWebhook(w http.ResponseWriter, r *http.Request
 	}
 	defer r.Body.Close()
 
-	go th.ChannelService.WebhookService(webhookRequest)
+	inboundMessages := webhookRequest.ToInboundMessages()
+	if len(inboundMessages) == 0 {
+		th.Logger.Warn("Received Infobip webhook with no results.")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	th.Logger.Info(fmt.Sprintf("Received %d messages in webhook batch.", len(inboundMessages)))
+
+	if err := th.InboundQueueService.Enqueue(inboundMessages); err != nil {
+		http.Error(w, "Failed to enqueue webhook event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// InfobipReportsWebhook ingests the delivery and seen reports Infobip posts to the notify URL
+// of outbound messages and records them against the stored outbound message.
+//
+// HTTP Status Codes:
+// - 200 OK: The reports were recorded.
+// - 400 Bad Request: The JSON payload could not be decoded.
+// - 500 Internal Server Error: At least one report could not be recorded, so Infobip retries the delivery.
+func (th *InfobipHandlers) InfobipReportsWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var reportRequest dto.InfobipReportResponse
+	if err := json.NewDecoder(r.Body).Decode(&reportRequest); err != nil {
+		http.Error(w, "Error to process JSON", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	statusEvents := reportRequest.ToStatusEvents()
+	th.Logger.Info(fmt.Sprintf("Received %d Infobip reports.", len(statusEvents)))
+
+	failed := false
+	for _, event := range statusEvents {
+		if _, err := th.MessageStatusService.RecordStatus(event); err != nil {
+			failed = true
+		}
+	}
+
+	if failed {
+		http.Error(w, "Failed to record reports", http.StatusInternalServerError)
+		return
+	}
 
 	w.WriteHeader(http.StatusOK)
 }
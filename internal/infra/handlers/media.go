@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/logger"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MediaHandlers struct {
+	Logger       *logger.Logger
+	MediaService Iservices.IMediaService
+}
+
+func NewMediaHandlers(logger *logger.Logger, mediaService Iservices.IMediaService) *MediaHandlers {
+	return &MediaHandlers{Logger: logger, MediaService: mediaService}
+}
+
+// GetSignedMedia serves a stored media through an expiring signed URL produced by the media service.
+//
+// Path Parameters:
+// - id (string): The ID of the stored media.
+//
+// Query Parameters:
+// - expires (string): Unix timestamp after which the URL is no longer valid.
+// - signature (string): HMAC signature of the media ID and expiry.
+//
+// HTTP Status Codes:
+// - 200 OK / 206 Partial Content: The media content, honouring Range requests.
+// - 403 Forbidden: The signature is invalid or the URL has expired.
+// - 404 Not Found: No media with this ID was stored.
+func (th *MediaHandlers) GetSignedMedia(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	query := r.URL.Query()
+
+	if !th.MediaService.VerifySignature(id, query.Get("expires"), query.Get("signature")) {
+		th.Logger.Warn(fmt.Sprintf("Rejected media request for %s from %s: invalid or expired signature", id, r.RemoteAddr))
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	th.serveMedia(w, r, id)
+}
+
+// GetMedia serves a stored media to API clients authenticated with the admin API key.
+func (th *MediaHandlers) GetMedia(w http.ResponseWriter, r *http.Request) {
+	th.serveMedia(w, r, mux.Vars(r)["id"])
+}
+
+func (th *MediaHandlers) serveMedia(w http.ResponseWriter, r *http.Request, id string) {
+	media, err := th.MediaService.FindMedia(id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		http.Error(w, "Media not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to load media %s: %v", id, err))
+		http.Error(w, "Failed to load media", http.StatusInternalServerError)
+		return
+	}
+
+	content, err := th.MediaService.Open(media)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to open media %s: %v", id, err))
+		http.Error(w, "Failed to load media", http.StatusInternalServerError)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", media.MimeType)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if media.Filename != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": media.Filename}))
+	}
+
+	if media.SHA256 != "" {
+		// Lets the Meta provider identify the content without downloading it again.
+		w.Header().Set("ETag", fmt.Sprintf(`"sha256-%s"`, media.SHA256))
+	}
+	http.ServeContent(w, r, "", media.CreatedAt, content)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/logger"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MessageStatusHandlers struct {
+	Logger               *logger.Logger
+	MessageStatusService Iservices.IMessageStatusService
+}
+
+func NewMessageStatusHandlers(logger *logger.Logger, messageStatusService Iservices.IMessageStatusService) *MessageStatusHandlers {
+	return &MessageStatusHandlers{Logger: logger, MessageStatusService: messageStatusService}
+}
+
+// GetMessage returns an outbound message with its status history.
+//
+// Path Parameters:
+// - provider (string): The provider that sent the message (meta, infobip).
+// - id (string): The provider message ID.
+//
+// HTTP Status Codes:
+// - 200 OK: The message record is returned as JSON.
+// - 404 Not Found: No message with this ID was recorded.
+func (th *MessageStatusHandlers) GetMessage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	message, err := th.MessageStatusService.FindMessage(vars["provider"], vars["id"])
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		http.Error(w, "Message not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to load message %s: %v", vars["id"], err))
+		http.Error(w, "Failed to load message", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, message)
+}
+
+// ListConversationMessages returns the outbound messages of a conversation, newest first.
+func (th *MessageStatusHandlers) ListConversationMessages(w http.ResponseWriter, r *http.Request) {
+	conversationID := mux.Vars(r)["conversationId"]
+
+	messages, err := th.MessageStatusService.FindConversationMessages(conversationID)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to load messages of conversation %s: %v", conversationID, err))
+		http.Error(w, "Failed to load messages", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, messages)
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+	"social-connector/internal/domain/dto"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/logger"
+	"social-connector/internal/middleware"
+)
+
+type StatsHandlers struct {
+	Logger               *logger.Logger
+	DeduplicationService Iservices.IDeduplicationService
+	InfobipAuth          *middleware.InfobipAuthMiddleware
+}
+
+func NewStatsHandlers(logger *logger.Logger, deduplicationService Iservices.IDeduplicationService, infobipAuth *middleware.InfobipAuthMiddleware) *StatsHandlers {
+	return &StatsHandlers{Logger: logger, DeduplicationService: deduplicationService, InfobipAuth: infobipAuth}
+}
+
+// GetStats returns the runtime counters of this instance since it started.
+func (th *StatsHandlers) GetStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, dto.Stats{
+		DuplicateInboundMessages: th.DeduplicationService.DuplicateCount(),
+		InfobipAuthFailures:      th.InfobipAuth.FailureCount(),
+	})
+}
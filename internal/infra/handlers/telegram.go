@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"social-connector/internal/domain/dto"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/logger"
+)
+
+type TelegramHandlers struct {
+	Logger              *logger.Logger
+	InboundQueueService Iservices.IInboundQueueService
+}
+
+func NewTelegramHandlers(logger *logger.Logger, inboundQueueService Iservices.IInboundQueueService) *TelegramHandlers {
+	return &TelegramHandlers{Logger: logger, InboundQueueService: inboundQueueService}
+}
+
+// TelegramWebhook ingests the updates Telegram posts to the bot's webhook.
+//
+// HTTP Status Codes:
+// - 200 OK: The update was queued, or skipped because it carries no message.
+// - 400 Bad Request: The JSON payload could not be decoded.
+// - 500 Internal Server Error: The update could not be queued, so Telegram retries the delivery.
+func (th *TelegramHandlers) TelegramWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var update dto.TelegramUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "Error to process JSON", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	inboundMessage, ok := update.ToInboundMessage()
+	if !ok {
+		th.Logger.Info(fmt.Sprintf("Skipped Telegram update %d without message", update.UpdateID))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := th.InboundQueueService.Enqueue([]dto.InboundMessage{inboundMessage}); err != nil {
+		http.Error(w, "Failed to enqueue webhook event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"social-connector/internal/domain/dto"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type TemplateHandlers struct {
+	Logger          *logger.Logger
+	TemplateService Iservices.ITemplateService
+}
+
+func NewTemplateHandlers(logger *logger.Logger, templateService Iservices.ITemplateService) *TemplateHandlers {
+	return &TemplateHandlers{Logger: logger, TemplateService: templateService}
+}
+
+// ListTemplates returns the templates of the local registry.
+func (th *TemplateHandlers) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, th.TemplateService.ListTemplates())
+}
+
+// GetTemplate returns a template of the local registry with its language variants.
+func (th *TemplateHandlers) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	template, ok := th.TemplateService.FindTemplate(mux.Vars(r)["name"])
+	if !ok {
+		http.Error(w, "Template not found", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, template)
+}
+
+// SendTemplate sends a template message.
+//
+// Request Body:
+// - dto.SendTemplateRequest: The provider, recipient, template, language and placeholder values.
+//
+// HTTP Status Codes:
+// - 201 Created: The message was accepted by the provider; the outbound message record is returned.
+// - 400 Bad Request: The JSON is invalid, the recipient is not a valid phone number or the placeholders do not match the template.
+// - 502 Bad Gateway: The provider rejected the message or could not be reached.
+// - 500 Internal Server Error: The message could not be sent for another reason.
+func (th *TemplateHandlers) SendTemplate(w http.ResponseWriter, r *http.Request) {
+	var request dto.SendTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Error to process JSON", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	message, err := th.TemplateService.SendTemplate(request)
+	switch {
+	case errors.Is(err, Iservices.ErrInvalidTemplateRequest):
+		th.Logger.Warn(fmt.Sprintf("Template %s to %s rejected: %v", request.Template, request.To, err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, Iservices.ErrTemplateNotSent):
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	case err != nil:
+		th.Logger.Error(fmt.Sprintf("Failed to send template %s to %s: %v", request.Template, request.To, err))
+		http.Error(w, "Failed to send template", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, message)
+}
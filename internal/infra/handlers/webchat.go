@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/logger"
+	"social-connector/internal/infra/webchat"
+	"social-connector/internal/middleware"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	webChatMaxFrameBytes = 16 << 10
+	webChatPingInterval  = 25 * time.Second
+	webChatPongWait      = 60 * time.Second
+	webChatWriteWait     = 10 * time.Second
+)
+
+// errWebChatRateLimited is reported to visitors sending messages faster than the configured limits.
+var errWebChatRateLimited = errors.New("too many messages, please wait a moment")
+
+// WebChatHandlers serves the anonymous website widget. Sessions and messages are rate limited
+// per session and per client IP, since every visitor message costs an AI call.
+type WebChatHandlers struct {
+	Logger           *logger.Logger
+	WebChatService   Iservices.IWebChatService
+	Upgrader         websocket.Upgrader
+	TrustProxy       bool
+	SessionLimiter   *webchat.Limiter
+	MessageLimiter   *webchat.Limiter
+	IPMessageLimiter *webchat.Limiter
+}
+
+func NewWebChatHandlers(logger *logger.Logger, webChatService Iservices.IWebChatService, config config.WebChatConfig) *WebChatHandlers {
+	return &WebChatHandlers{
+		Logger:         logger,
+		WebChatService: webChatService,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return middleware.OriginAllowed(config.AllowedOrigins, r.Header.Get("Origin"))
+			},
+		},
+		TrustProxy:       config.TrustProxy,
+		SessionLimiter:   webchat.NewLimiter(config.IPSessionsPerHour, time.Hour),
+		MessageLimiter:   webchat.NewLimiter(config.SessionMessagesPerMinute, time.Minute),
+		IPMessageLimiter: webchat.NewLimiter(config.IPMessagesPerMinute, time.Minute),
+	}
+}
+
+// Widget serves the embeddable chat widget. Websites load it with
+// <script src="https://connector.example.com/webchat/widget.js" async></script>.
+func (th *WebChatHandlers) Widget(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Write(webchat.WidgetScript)
+}
+
+// CreateSession starts an anonymous visitor session.
+//
+// HTTP Status Codes:
+// - 201 Created: The session ID, its token and the token expiry are returned.
+// - 429 Too Many Requests: The client IP created too many sessions in the last hour.
+func (th *WebChatHandlers) CreateSession(w http.ResponseWriter, r *http.Request) {
+	ip := middleware.ClientIP(r, th.TrustProxy)
+	if !th.SessionLimiter.Allow(ip) {
+		th.Logger.Warn(fmt.Sprintf("Web chat session rejected for %s: too many sessions", ip))
+		http.Error(w, "Too many sessions", http.StatusTooManyRequests)
+		return
+	}
+
+	session, err := th.WebChatService.CreateSession()
+	if err != nil {
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, session)
+}
+
+// PostMessage receives a visitor message when the widget uses the SSE fallback instead of the WebSocket.
+//
+// Request Body:
+// - dto.WebChatInbound: The text typed by the visitor, or the option it picked.
+//
+// HTTP Status Codes:
+// - 202 Accepted: The message was queued; the reply arrives on the event stream.
+// - 400 Bad Request: The JSON is invalid or the message is empty or too long.
+// - 401 Unauthorized: The session token is missing, invalid or expired.
+// - 429 Too Many Requests: The session or the client IP sent too many messages in the last minute.
+func (th *WebChatHandlers) PostMessage(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := th.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var message dto.WebChatInbound
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, webChatMaxFrameBytes)).Decode(&message); err != nil {
+		http.Error(w, "Error to process JSON", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := th.allowMessage(sessionID, middleware.ClientIP(r, th.TrustProxy)); err != nil {
+		th.Logger.Warn(fmt.Sprintf("Web chat message of session %s rejected: %v", sessionID, err))
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+
+	if err := th.WebChatService.ReceiveMessage(sessionID, message); err != nil {
+		th.Logger.Warn(fmt.Sprintf("Web chat message of session %s rejected: %v", sessionID, err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// History returns the transcript of the session, so the widget can restore the conversation after a reload.
+func (th *WebChatHandlers) History(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := th.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	transcript, err := th.WebChatService.Transcript(sessionID)
+	if err != nil {
+		http.Error(w, "Failed to load transcript", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, transcript)
+}
+
+// Socket upgrades the request to a WebSocket. The visitor sends dto.WebChatInbound frames and
+// receives dto.WebChatEvent frames with the agent replies. The token goes in the token query
+// parameter, browsers cannot set headers on WebSocket requests.
+func (th *WebChatHandlers) Socket(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := th.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	conn, err := th.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		th.Logger.Warn(fmt.Sprintf("Web chat WebSocket upgrade of session %s failed: %v", sessionID, err))
+		return
+	}
+	defer conn.Close()
+
+	ip := middleware.ClientIP(r, th.TrustProxy)
+	events, unsubscribe := th.WebChatService.Subscribe(sessionID)
+	defer unsubscribe()
+
+	rejected := make(chan dto.WebChatEvent, 4)
+	done := make(chan struct{})
+	defer close(done)
+	go th.writeSocket(conn, events, rejected, done)
+
+	conn.SetReadLimit(webChatMaxFrameBytes)
+	conn.SetReadDeadline(time.Now().Add(webChatPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(webChatPongWait))
+	})
+
+	for {
+		var message dto.WebChatInbound
+		if err := conn.ReadJSON(&message); err != nil {
+			return
+		}
+
+		err := th.allowMessage(sessionID, ip)
+		if err == nil {
+			err = th.WebChatService.ReceiveMessage(sessionID, message)
+		}
+		if err != nil {
+			th.Logger.Warn(fmt.Sprintf("Web chat message of session %s rejected: %v", sessionID, err))
+			select {
+			case rejected <- dto.WebChatEvent{Type: dto.WEBCHAT_EVENT_ERROR, ID: message.ID, Error: err.Error(), SentAt: time.Now()}:
+			default:
+			}
+		}
+	}
+}
+
+// writeSocket is the only writer of the connection: it forwards events and keeps the
+// connection alive with pings until the reader stops or a write fails.
+func (th *WebChatHandlers) writeSocket(conn *websocket.Conn, events <-chan dto.WebChatEvent, rejected <-chan dto.WebChatEvent, done <-chan struct{}) {
+	ticker := time.NewTicker(webChatPingInterval)
+	defer ticker.Stop()
+	// Unblocks the reader when a write fails.
+	defer conn.Close()
+
+	for {
+		var event dto.WebChatEvent
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(webChatWriteWait)); err != nil {
+				return
+			}
+			continue
+		case event = <-rejected:
+		case received, ok := <-events:
+			if !ok {
+				return
+			}
+			event = received
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(webChatWriteWait))
+		if err := conn.WriteJSON(event); err != nil {
+			return
+		}
+	}
+}
+
+// Events streams the agent replies as server-sent events, the fallback for browsers or
+// proxies without WebSocket support. The token goes in the token query parameter.
+func (th *WebChatHandlers) Events(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := th.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := th.WebChatService.Subscribe(sessionID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(webChatPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				th.Logger.Error(fmt.Sprintf("Failed to encode web chat event %s: %v", event.ID, err))
+				continue
+			}
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+		}
+		flusher.Flush()
+	}
+}
+
+// allowMessage counts a visitor message against the session and client IP limits.
+func (th *WebChatHandlers) allowMessage(sessionID string, ip string) error {
+	sessionAllowed := th.MessageLimiter.Allow(sessionID)
+	ipAllowed := th.IPMessageLimiter.Allow(ip)
+	if !sessionAllowed || !ipAllowed {
+		return errWebChatRateLimited
+	}
+	return nil
+}
+
+// authenticate verifies the session token sent as a Bearer token or in the token query
+// parameter and returns the session ID. On failure it answers with 401.
+func (th *WebChatHandlers) authenticate(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token := r.URL.Query().Get("token")
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+
+	sessionID, err := th.WebChatService.VerifyToken(token)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return "", false
+	}
+	return sessionID, true
+}
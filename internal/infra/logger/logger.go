@@ -3,7 +3,6 @@ package logger
 import (
 	"context"
 	"os"
-	"social-connector/internal/config"
 
 	"github.com/sirupsen/logrus"
 )
@@ -14,12 +13,10 @@ type Logger struct {
 }
 
 // NewLogger initializes a new instance of Logger with optional configuration.
-func NewLogger(ctx context.Context, jsonFormat bool) *Logger {
+func NewLogger(ctx context.Context, jsonFormat bool, logLevel string) *Logger {
 	logger := logrus.New()
 	logger.Out = os.Stdout
 
-	logLevel := config.GetEnv("LOG_LEVEL")
-
 	level, err := logrus.ParseLevel(logLevel)
 	if err != nil {
 		level = logrus.InfoLevel
@@ -3,7 +3,14 @@ package provider
 import "social-connector/internal/domain/dto"
 
 type IWhatsAppProvider interface {
-	SendTextMessage(to, message string) error
-	SendAudioMessage(to, audioLink string) error
+	SendTextMessage(to, message string) (dto.SendResult, error)
+	SendAudioMessage(to, audioLink string) (dto.SendResult, error)
+	SendMediaMessage(to string, media dto.OutboundMedia) (dto.SendResult, error)
+	SendLocationMessage(to string, location dto.OutboundLocation) (dto.SendResult, error)
+	SendContactsMessage(to string, contacts []dto.OutboundContact) (dto.SendResult, error)
+	SendTemplateMessage(to string, template dto.TemplateMessage) (dto.SendResult, error)
+	SendButtonsMessage(to string, message dto.ButtonsMessage) (dto.SendResult, error)
+	SendListMessage(to string, message dto.ListMessage) (dto.SendResult, error)
 	GenerateOAuth2Token() (*dto.TokenResponse, error)
+	DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error)
 }
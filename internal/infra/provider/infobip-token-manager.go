@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/infra/logger"
+	"sync"
+	"time"
+)
+
+// InfobipTokenManager caches the Infobip OAuth2 access token and refreshes it shortly
+// before it expires. It is safe for concurrent use: concurrent callers that find the
+// cache empty or expired wait for a single refresh request instead of issuing their own.
+type InfobipTokenManager struct {
+	Logger        *logger.Logger
+	HttpClient    *http.Client
+	BaseURL       string
+	ClientID      string
+	ClientSecret  string
+	RefreshMargin time.Duration
+
+	mu        sync.Mutex
+	token     *dto.TokenResponse
+	expiresAt time.Time
+}
+
+func NewInfobipTokenManager(logger *logger.Logger, httpClient *http.Client, config config.InfobipConfig) *InfobipTokenManager {
+	return &InfobipTokenManager{
+		Logger:        logger,
+		HttpClient:    httpClient,
+		BaseURL:       config.URL,
+		ClientID:      config.ClientID,
+		ClientSecret:  config.ClientSecret,
+		RefreshMargin: time.Duration(config.TokenRefreshMarginSeconds) * time.Second,
+	}
+}
+
+// Token returns the cached access token, requesting a new one when it is missing or about to expire.
+func (th *InfobipTokenManager) Token() (*dto.TokenResponse, error) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	if th.token != nil && time.Now().Before(th.expiresAt) {
+		return th.token, nil
+	}
+
+	token, err := th.requestToken()
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to refresh Infobip OAuth2 token: %v", err))
+		return nil, err
+	}
+
+	lifetime := time.Duration(token.ExpiresIn)*time.Second - th.RefreshMargin
+	if lifetime < 0 {
+		lifetime = 0
+	}
+
+	th.token = token
+	th.expiresAt = time.Now().Add(lifetime)
+	th.Logger.Debug(fmt.Sprintf("Infobip OAuth2 token refreshed, expires in %d seconds", token.ExpiresIn))
+
+	return th.token, nil
+}
+
+// Invalidate drops the cached token if it is still the given one, so the next call to Token
+// requests a new one. Tokens refreshed in the meantime by another caller are kept.
+func (th *InfobipTokenManager) Invalidate(accessToken string) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	if th.token != nil && th.token.AccessToken == accessToken {
+		th.token = nil
+		th.expiresAt = time.Time{}
+	}
+}
+
+func (th *InfobipTokenManager) requestToken() (*dto.TokenResponse, error) {
+	if th.BaseURL == "" {
+		return nil, fmt.Errorf("INFOBIP_URL is not set")
+	}
+	if th.ClientID == "" || th.ClientSecret == "" {
+		return nil, fmt.Errorf("INFOBIP_CLIENT_ID and INFOBIP_CLIENT_SECRET must be set")
+	}
+
+	apiURL := fmt.Sprintf("%s/auth/1/oauth2/token", th.BaseURL)
+
+	data := url.Values{}
+	data.Set("client_id", th.ClientID)
+	data.Set("client_secret", th.ClientSecret)
+	data.Set("grant_type", "client_credentials")
+
+	req, err := http.NewRequest("POST", apiURL, bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := th.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected HTTP status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResponse dto.TokenResponse
+	err = json.NewDecoder(resp.Body).Decode(&tokenResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding response JSON: %v", err)
+	}
+
+	if tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("token response did not include an access token")
+	}
+
+	return &tokenResponse, nil
+}
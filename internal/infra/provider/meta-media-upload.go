@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+	"strings"
+	"time"
+)
+
+// maxMetaUploadSize is the largest file the Graph API accepts (documents, 100MB).
+const maxMetaUploadSize = 100 * 1024 * 1024
+
+// sha256ETagPrefix starts the ETags carrying the SHA-256 of the content, as served by the media endpoint.
+const sha256ETagPrefix = `"sha256-`
+
+// uploadFromLink returns a Meta media ID for the content behind link, uploading it only once
+// while the cached media ID has not expired.
+//
+// The cache is looked up first by the ETag of the link, asked with a HEAD request, so repeated
+// sends of the same file are neither downloaded nor uploaded again. Links without an ETag are
+// downloaded and looked up by the SHA-256 of their content.
+func (th *MetaWhatsAppProvider) uploadFromLink(link string, filename string) (string, error) {
+	linkKey := ""
+	if etag := th.headETag(link); etag != "" {
+		if strings.HasPrefix(etag, sha256ETagPrefix) {
+			linkKey = th.uploadCacheKey(strings.TrimSuffix(strings.TrimPrefix(etag, sha256ETagPrefix), `"`))
+		} else {
+			sum := sha256.Sum256([]byte(link + "\n" + etag))
+			linkKey = fmt.Sprintf("%s:etag:%s", th.PhoneNumberID, hex.EncodeToString(sum[:]))
+		}
+
+		if cached, ok := th.cachedUpload(linkKey); ok {
+			return cached.MediaID, nil
+		}
+	}
+
+	res, err := th.HttpClient.Get(link)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", link, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected HTTP status %d downloading %s", res.StatusCode, link)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxMetaUploadSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", link, err)
+	}
+	if len(data) > maxMetaUploadSize {
+		return "", fmt.Errorf("%s is larger than %d bytes", link, maxMetaUploadSize)
+	}
+
+	mimeType, _, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if err != nil || mimeType == "application/octet-stream" {
+		mimeType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	contentKey := th.uploadCacheKey(checksum)
+
+	upload, cached := th.cachedUpload(contentKey)
+	if !cached {
+		mediaID, err := th.upload(data, mimeType, filename)
+		if err != nil {
+			return "", err
+		}
+		th.Logger.Info(fmt.Sprintf("Uploaded %s media to Meta as %s, %d bytes", mimeType, mediaID, len(data)))
+
+		upload = entities.MediaUpload{
+			Provider:  dto.PROVIDER_META,
+			MediaID:   mediaID,
+			SHA256:    checksum,
+			MimeType:  mimeType,
+			Size:      int64(len(data)),
+			ExpiresAt: time.Now().Add(th.MediaIDTTL),
+			CreatedAt: time.Now(),
+		}
+		th.saveUpload(contentKey, upload)
+	}
+
+	if linkKey != "" && linkKey != contentKey {
+		th.saveUpload(linkKey, upload)
+	}
+
+	return upload.MediaID, nil
+}
+
+func (th *MetaWhatsAppProvider) uploadCacheKey(checksum string) string {
+	return fmt.Sprintf("%s:%s", th.PhoneNumberID, checksum)
+}
+
+// headETag returns the ETag of a link, or an empty string when the server does not tell it.
+func (th *MetaWhatsAppProvider) headETag(link string) string {
+	res, err := th.HttpClient.Head(link)
+	if err != nil {
+		th.Logger.Debug(fmt.Sprintf("Failed to check ETag of %s: %v", link, err))
+		return ""
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return ""
+	}
+	return res.Header.Get("ETag")
+}
+
+// cachedUpload returns the upload cached under key, if its media ID has not expired.
+func (th *MetaWhatsAppProvider) cachedUpload(key string) (entities.MediaUpload, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cached, err := th.MediaUploads.Find(ctx, key)
+	if err != nil || !time.Now().Before(cached.ExpiresAt) {
+		return entities.MediaUpload{}, false
+	}
+
+	th.Logger.Debug(fmt.Sprintf("Reusing Meta media %s for %s", cached.MediaID, key))
+	return cached, true
+}
+
+func (th *MetaWhatsAppProvider) saveUpload(key string, upload entities.MediaUpload) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	upload.ID = key
+	if err := th.MediaUploads.Save(ctx, upload); err != nil {
+		th.Logger.Warn(fmt.Sprintf("Failed to cache Meta media %s: %v", upload.MediaID, err))
+	}
+}
+
+// upload posts a file to the Graph API media endpoint of the configured phone number.
+func (th *MetaWhatsAppProvider) upload(data []byte, mimeType string, filename string) (string, error) {
+	if filename == "" {
+		filename = "media"
+		if extensions, err := mime.ExtensionsByType(mimeType); err == nil && len(extensions) > 0 {
+			filename += extensions[0]
+		}
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("messaging_product", "whatsapp")
+	writer.WriteField("type", mimeType)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": "file", "filename": filename}))
+	header.Set("Content-Type", mimeType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return "", fmt.Errorf("failed to build upload request: %w", err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	url := fmt.Sprintf("%s/%s/%s/media", th.GraphAPIURL, th.GraphAPIVersion, th.PhoneNumberID)
+	req, err := http.NewRequest("POST", url, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", th.AccessToken))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	res, err := th.HttpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %s uploading media response_body %s", res.Status, string(responseBody)))
+		return "", fmt.Errorf("unexpected HTTP status: %s", res.Status)
+	}
+
+	var response struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil || response.ID == "" {
+		return "", fmt.Errorf("media upload returned no media ID")
+	}
+	return response.ID, nil
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/infra/logger"
+)
+
+// MetaMessengerProvider sends messages through the Messenger Platform Send API, used by both
+// Facebook Messenger pages and Instagram professional accounts. Recipients are page-scoped
+// (PSID) or Instagram-scoped (IGSID) IDs.
+type MetaMessengerProvider struct {
+	Logger          *logger.Logger
+	HttpClient      *http.Client
+	Provider        string
+	GraphAPIURL     string
+	GraphAPIVersion string
+	AccessToken     string
+}
+
+// NewMessengerProvider sends Facebook Messenger messages with the page access token.
+func NewMessengerProvider(logger *logger.Logger, httpClient *http.Client, config config.MetaConfig) *MetaMessengerProvider {
+	return &MetaMessengerProvider{Logger: logger, HttpClient: httpClient, Provider: dto.PROVIDER_MESSENGER, GraphAPIURL: config.GraphAPIURL, GraphAPIVersion: config.GraphAPIVersion, AccessToken: config.MessengerAccessToken}
+}
+
+// NewInstagramProvider sends Instagram Direct messages with the access token of the page
+// linked to the Instagram account.
+func NewInstagramProvider(logger *logger.Logger, httpClient *http.Client, config config.MetaConfig) *MetaMessengerProvider {
+	return &MetaMessengerProvider{Logger: logger, HttpClient: httpClient, Provider: dto.PROVIDER_INSTAGRAM, GraphAPIURL: config.GraphAPIURL, GraphAPIVersion: config.GraphAPIVersion, AccessToken: config.InstagramAccessToken}
+}
+
+// SendTextMessage sends a text message, with quick replies when any are given.
+//
+// Parameters:
+//   - to: string - The page-scoped or Instagram-scoped ID of the recipient.
+//   - message: string - The content of the text message to be sent.
+//   - quickReplies: []dto.MessengerQuickReply - Optional quick replies shown under the message.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Meta, used to correlate delivery and read receipts.
+//   - error: Returns an error if the input is invalid or the Send API rejects the message.
+func (th *MetaMessengerProvider) SendTextMessage(to string, message string, quickReplies []dto.MessengerQuickReply) (dto.SendResult, error) {
+	if to == "" || message == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) and message cannot be empty")
+	}
+	if len(quickReplies) > dto.MAX_QUICK_REPLIES {
+		return dto.SendResult{}, fmt.Errorf("at most %d quick replies are allowed", dto.MAX_QUICK_REPLIES)
+	}
+
+	return th.sendMessage(to, dto.MessengerMessage{Text: message, QuickReplies: quickReplies})
+}
+
+// SendAttachmentMessage sends an image, video, audio or file attachment by URL. Instagram does
+// not accept file attachments. Captions are not supported by the Send API and are dropped.
+func (th *MetaMessengerProvider) SendAttachmentMessage(to string, media dto.OutboundMedia) (dto.SendResult, error) {
+	if err := media.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	attachmentType := ""
+	switch media.Type {
+	case dto.OUTBOUND_MEDIA_IMAGE, dto.OUTBOUND_MEDIA_STICKER:
+		attachmentType = "image"
+	case dto.OUTBOUND_MEDIA_VIDEO:
+		attachmentType = "video"
+	case dto.OUTBOUND_MEDIA_AUDIO:
+		attachmentType = "audio"
+	case dto.OUTBOUND_MEDIA_DOCUMENT:
+		if th.Provider == dto.PROVIDER_INSTAGRAM {
+			return dto.SendResult{}, fmt.Errorf("instagram does not support document attachments")
+		}
+		attachmentType = "file"
+	default:
+		return dto.SendResult{}, fmt.Errorf("unsupported media type %s", media.Type)
+	}
+
+	attachment := &dto.MessengerAttachment{
+		Type:    attachmentType,
+		Payload: dto.MessengerAttachmentPayload{URL: media.Link, AttachmentID: media.ID},
+	}
+	return th.sendMessage(to, dto.MessengerMessage{Attachment: attachment})
+}
+
+// DownloadMedia fetches an inbound attachment. Webhooks carry a signed CDN URL that does not
+// require the access token.
+func (th *MetaMessengerProvider) DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error) {
+	if media.URL == "" {
+		return dto.DownloadedMedia{}, fmt.Errorf("media has no URL")
+	}
+
+	res, err := th.HttpClient.Get(media.URL)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("HTTP request failed %v", err))
+		return dto.DownloadedMedia{}, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		res.Body.Close()
+		th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %d downloading %s attachment", res.StatusCode, th.Provider))
+		return dto.DownloadedMedia{}, fmt.Errorf("unexpected HTTP status: %d", res.StatusCode)
+	}
+
+	mimeType := media.MimeType
+	if mimeType == "" {
+		mimeType = res.Header.Get("Content-Type")
+	}
+	return dto.DownloadedMedia{Body: res.Body, MimeType: mimeType, Filename: media.Filename}, nil
+}
+
+// sendMessage posts a message to the Send API as a reply to the user (messaging type RESPONSE),
+// which is allowed within 24 hours of the user's last message.
+func (th *MetaMessengerProvider) sendMessage(to string, message dto.MessengerMessage) (dto.SendResult, error) {
+	result := dto.SendResult{Provider: th.Provider, Status: dto.MESSAGE_STATUS_SENT}
+
+	if th.AccessToken == "" {
+		th.Logger.Error(fmt.Sprintf("Access token of %s is not set", th.Provider))
+		return result, fmt.Errorf("access token of %s is not set", th.Provider)
+	}
+
+	payload, err := json.Marshal(dto.MessengerSendRequest{
+		Recipient:     dto.MessagingParty{ID: to},
+		MessagingType: dto.MESSENGER_MESSAGE_TYPE,
+		Message:       message,
+	})
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to marshal payload %v", err))
+		return result, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/%s/me/messages", th.GraphAPIURL, th.GraphAPIVersion)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to create HTTP request %v", err))
+		return result, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", th.AccessToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := th.HttpClient.Do(req)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("HTTP request failed %v", err))
+		return result, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to read response body %v", err))
+		return result, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %s response_body %s", res.Status, string(body)))
+		return result, fmt.Errorf("unexpected HTTP status: %s", res.Status)
+	}
+
+	var response dto.MessengerSendResponse
+	if err := json.Unmarshal(body, &response); err != nil || response.MessageID == "" {
+		th.Logger.Warn(fmt.Sprintf("Message sent but no message ID in response_body %s", string(body)))
+		return result, nil
+	}
+
+	result.ProviderMessageID = response.MessageID
+	th.Logger.Info(fmt.Sprintf("Message %s sent successfully %s", result.ProviderMessageID, res.Status))
+	return result, nil
+}
@@ -0,0 +1,499 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/interfaces/repository"
+	"social-connector/internal/infra/logger"
+	"strconv"
+	"time"
+)
+
+type MetaWhatsAppProvider struct {
+	Logger          *logger.Logger
+	HttpClient      *http.Client
+	GraphAPIURL     string
+	GraphAPIVersion string
+	PhoneNumberID   string
+	AccessToken     string
+	UploadMedia     bool
+	MediaIDTTL      time.Duration
+	MediaUploads    repository.MediaUploadRepository
+}
+
+func NewMetaWhatsAppProvider(logger *logger.Logger, httpClient *http.Client, config config.MetaConfig, mediaUploads repository.MediaUploadRepository) *MetaWhatsAppProvider {
+	return &MetaWhatsAppProvider{
+		Logger:          logger,
+		HttpClient:      httpClient,
+		GraphAPIURL:     config.GraphAPIURL,
+		GraphAPIVersion: config.GraphAPIVersion,
+		PhoneNumberID:   config.PhoneNumberID,
+		AccessToken:     config.AccessToken,
+		UploadMedia:     config.UploadMedia,
+		MediaIDTTL:      time.Duration(config.MediaIDTTLHours) * time.Hour,
+		MediaUploads:    mediaUploads,
+	}
+}
+
+// Start creates the indexes backing the media upload cache.
+func (th *MetaWhatsAppProvider) Start(ctx context.Context) error {
+	if err := th.MediaUploads.EnsureIndexes(ctx); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to create media upload indexes: %v", err))
+		return err
+	}
+	return nil
+}
+
+// SendTextMessage sends a text message to a recipient's phone number using the Meta Cloud API.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - message: string - The content of the text message to be sent.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Meta, used to correlate status notifications.
+//   - error: Returns an error if any step of the process fails, including input validation,
+//     payload construction, HTTP request failure, or unexpected API response.
+func (th *MetaWhatsAppProvider) SendTextMessage(to, message string) (dto.SendResult, error) {
+	if to == "" || message == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) and message cannot be empty")
+	}
+
+	payloadData := dto.IWhatsAppMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               to,
+		Type:             "text",
+		Text:             &dto.WhatsAppMessageText{PreviewURL: false, Body: message},
+	}
+
+	return th.sendMessage(payloadData)
+}
+
+// SendAudioMessage sends an audio message to a recipient's phone number using the Meta Cloud API.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - audioLink: string - A public URL pointing to the audio file to be sent.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Meta, used to correlate status notifications.
+//   - error: Returns an error if any step of the process fails, including input validation,
+//     payload construction, HTTP request failure, or unexpected API response.
+func (th *MetaWhatsAppProvider) SendAudioMessage(to, audioLink string) (dto.SendResult, error) {
+	if to == "" || audioLink == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) and audio link cannot be empty")
+	}
+
+	return th.SendMediaMessage(to, dto.OutboundMedia{Type: dto.OUTBOUND_MEDIA_AUDIO, Link: audioLink})
+}
+
+// SendMediaMessage sends an image, video, document, sticker or audio using the Meta Cloud API.
+//
+// With meta.upload_media enabled, media given by link is uploaded to the Graph API once and the
+// cached media ID is reused for later sends of the same content. If the upload fails the link is
+// sent instead and Meta fetches it.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - media: dto.OutboundMedia - The media type, a public link or a media ID uploaded earlier, and the optional caption and filename.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Meta, used to correlate status notifications.
+//   - error: Returns an error if the media is invalid for its type or the request fails.
+func (th *MetaWhatsAppProvider) SendMediaMessage(to string, media dto.OutboundMedia) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := media.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	if media.ID == "" && th.UploadMedia {
+		mediaID, err := th.uploadFromLink(media.Link, media.Filename)
+		if err != nil {
+			th.Logger.Warn(fmt.Sprintf("Failed to upload %s to Meta, sending the link instead: %v", media.Link, err))
+		} else {
+			media.ID = mediaID
+		}
+	}
+
+	object := &dto.WhatsAppMediaObject{ID: media.ID, Link: media.Link, Caption: media.Caption, Filename: media.Filename}
+	if object.ID != "" {
+		object.Link = ""
+	}
+
+	payloadData := dto.IWhatsAppMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               to,
+		Type:             media.Type,
+	}
+	switch media.Type {
+	case dto.OUTBOUND_MEDIA_IMAGE:
+		payloadData.Image = object
+	case dto.OUTBOUND_MEDIA_VIDEO:
+		payloadData.Video = object
+	case dto.OUTBOUND_MEDIA_DOCUMENT:
+		payloadData.Document = object
+	case dto.OUTBOUND_MEDIA_STICKER:
+		payloadData.Sticker = object
+	case dto.OUTBOUND_MEDIA_AUDIO:
+		payloadData.Audio = object
+	}
+
+	return th.sendMessage(payloadData)
+}
+
+// SendLocationMessage sends a location pin using the Meta Cloud API.
+func (th *MetaWhatsAppProvider) SendLocationMessage(to string, location dto.OutboundLocation) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := location.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	return th.sendMessage(dto.IWhatsAppMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               to,
+		Type:             "location",
+		Location:         &location,
+	})
+}
+
+// SendContactsMessage sends one or more contact cards using the Meta Cloud API.
+func (th *MetaWhatsAppProvider) SendContactsMessage(to string, contacts []dto.OutboundContact) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := dto.ValidateContacts(contacts); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	payloadData := dto.IWhatsAppMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               to,
+		Type:             "contacts",
+	}
+	for _, contact := range contacts {
+		card := dto.WhatsAppContact{
+			Name:   dto.WhatsAppContactName{FormattedName: contact.FormattedName, FirstName: contact.FirstName, LastName: contact.LastName},
+			Phones: contact.Phones,
+			Emails: contact.Emails,
+		}
+		if contact.Company != "" {
+			card.Org = &dto.WhatsAppContactOrg{Company: contact.Company}
+		}
+		payloadData.Contacts = append(payloadData.Contacts, card)
+	}
+
+	return th.sendMessage(payloadData)
+}
+
+// SendTemplateMessage sends an approved template (HSM) using the Meta Cloud API. Templates can
+// be sent outside the 24-hour customer service window.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - template: dto.TemplateMessage - The template name, language and the values of its header, body and button placeholders.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Meta, used to correlate status notifications.
+//   - error: Returns an error if any step of the process fails, including input validation,
+//     payload construction, HTTP request failure, or unexpected API response.
+func (th *MetaWhatsAppProvider) SendTemplateMessage(to string, template dto.TemplateMessage) (dto.SendResult, error) {
+	if to == "" || template.Name == "" || template.Language == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to), template name and language cannot be empty")
+	}
+
+	payloadTemplate := &dto.WhatsAppTemplate{
+		Name:     template.Name,
+		Language: dto.WhatsAppTemplateLanguage{Code: template.Language},
+	}
+
+	if header := template.Header; header != nil {
+		parameter := dto.WhatsAppTemplateParameter{Type: header.Type}
+		media := &dto.WhatsAppMediaObject{Link: header.MediaURL, Filename: header.Filename}
+		switch header.Type {
+		case dto.TEMPLATE_HEADER_TEXT:
+			parameter.Text = header.Text
+		case dto.TEMPLATE_HEADER_IMAGE:
+			parameter.Image = media
+		case dto.TEMPLATE_HEADER_VIDEO:
+			parameter.Video = media
+		case dto.TEMPLATE_HEADER_DOCUMENT:
+			parameter.Document = media
+		default:
+			return dto.SendResult{}, fmt.Errorf("unsupported template header type %s", header.Type)
+		}
+		payloadTemplate.Components = append(payloadTemplate.Components, dto.WhatsAppTemplateComponent{
+			Type:       "header",
+			Parameters: []dto.WhatsAppTemplateParameter{parameter},
+		})
+	}
+
+	if len(template.Body) > 0 {
+		body := dto.WhatsAppTemplateComponent{Type: "body", Parameters: []dto.WhatsAppTemplateParameter{}}
+		for _, value := range template.Body {
+			body.Parameters = append(body.Parameters, dto.WhatsAppTemplateParameter{Type: "text", Text: value})
+		}
+		payloadTemplate.Components = append(payloadTemplate.Components, body)
+	}
+
+	for _, button := range template.Buttons {
+		parameter := dto.WhatsAppTemplateParameter{Type: "text", Text: button.Parameter}
+		if button.Type == dto.TEMPLATE_BUTTON_QUICK_REPLY {
+			parameter = dto.WhatsAppTemplateParameter{Type: "payload", Payload: button.Parameter}
+		}
+		payloadTemplate.Components = append(payloadTemplate.Components, dto.WhatsAppTemplateComponent{
+			Type:       "button",
+			SubType:    button.Type,
+			Index:      strconv.Itoa(button.Index),
+			Parameters: []dto.WhatsAppTemplateParameter{parameter},
+		})
+	}
+
+	payloadData := dto.IWhatsAppMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               to,
+		Type:             "template",
+		Template:         payloadTemplate,
+	}
+
+	return th.sendMessage(payloadData)
+}
+
+// SendButtonsMessage sends a message with up to three reply buttons using the Meta Cloud API.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - message: dto.ButtonsMessage - The header, body, footer and the reply buttons.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Meta, used to correlate status notifications.
+//   - error: Returns an error if the message breaks the WhatsApp limits or the request fails.
+func (th *MetaWhatsAppProvider) SendButtonsMessage(to string, message dto.ButtonsMessage) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := message.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	interactive := metaInteractive("button", message.Header, message.Body, message.Footer)
+	for _, button := range message.Buttons {
+		interactive.Action.Buttons = append(interactive.Action.Buttons, dto.WhatsAppInteractiveButton{Type: "reply", Reply: button})
+	}
+
+	return th.sendInteractive(to, interactive)
+}
+
+// SendListMessage sends a list message using the Meta Cloud API.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - message: dto.ListMessage - The header, body, footer, the text of the button opening the list and its sections.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Meta, used to correlate status notifications.
+//   - error: Returns an error if the message breaks the WhatsApp limits or the request fails.
+func (th *MetaWhatsAppProvider) SendListMessage(to string, message dto.ListMessage) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := message.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	interactive := metaInteractive("list", message.Header, message.Body, message.Footer)
+	interactive.Action.Button = message.ButtonText
+	for _, section := range message.Sections {
+		interactive.Action.Sections = append(interactive.Action.Sections, dto.WhatsAppInteractiveSection{Title: section.Title, Rows: section.Rows})
+	}
+
+	return th.sendInteractive(to, interactive)
+}
+
+func metaInteractive(interactiveType string, header string, body string, footer string) *dto.WhatsAppInteractive {
+	interactive := &dto.WhatsAppInteractive{Type: interactiveType, Body: dto.WhatsAppInteractiveText{Text: body}}
+	if header != "" {
+		interactive.Header = &dto.WhatsAppInteractiveText{Type: "text", Text: header}
+	}
+	if footer != "" {
+		interactive.Footer = &dto.WhatsAppInteractiveText{Text: footer}
+	}
+	return interactive
+}
+
+func (th *MetaWhatsAppProvider) sendInteractive(to string, interactive *dto.WhatsAppInteractive) (dto.SendResult, error) {
+	return th.sendMessage(dto.IWhatsAppMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               to,
+		Type:             "interactive",
+		Interactive:      interactive,
+	})
+}
+
+// GenerateOAuth2Token returns the configured Meta access token.
+//
+// The Meta Cloud API authenticates with a long-lived system user token instead of
+// an OAuth2 client credentials flow, so no request is made here.
+func (th *MetaWhatsAppProvider) GenerateOAuth2Token() (*dto.TokenResponse, error) {
+	if th.AccessToken == "" {
+		return &dto.TokenResponse{}, fmt.Errorf("WHATSAPP_ACCESS_TOKEN is not set")
+	}
+
+	return &dto.TokenResponse{AccessToken: th.AccessToken}, nil
+}
+
+// DownloadMedia fetches an inbound media file. Meta webhooks only carry the media ID, which is
+// resolved through the Graph API into a short-lived URL that also requires the access token.
+//
+// Parameters:
+//   - media: dto.InboundMedia - The media of an inbound message.
+//
+// Returns:
+//   - dto.DownloadedMedia: The open media stream and its MIME type; the caller closes the body.
+//   - error: Returns an error if the media ID cannot be resolved or the download fails.
+func (th *MetaWhatsAppProvider) DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error) {
+	downloadURL := media.URL
+	mimeType := media.MimeType
+
+	if media.ID != "" {
+		res, err := th.get(fmt.Sprintf("%s/%s/%s", th.GraphAPIURL, th.GraphAPIVersion, media.ID))
+		if err != nil {
+			return dto.DownloadedMedia{}, err
+		}
+		defer res.Body.Close()
+
+		var info dto.MetaMediaInfo
+		if err := json.NewDecoder(res.Body).Decode(&info); err != nil || info.URL == "" {
+			return dto.DownloadedMedia{}, fmt.Errorf("failed to resolve media %s", media.ID)
+		}
+		downloadURL = info.URL
+		if info.MimeType != "" {
+			mimeType = info.MimeType
+		}
+	}
+
+	if downloadURL == "" {
+		return dto.DownloadedMedia{}, fmt.Errorf("media has no ID nor URL")
+	}
+
+	res, err := th.get(downloadURL)
+	if err != nil {
+		return dto.DownloadedMedia{}, err
+	}
+	if mimeType == "" {
+		mimeType = res.Header.Get("Content-Type")
+	}
+
+	return dto.DownloadedMedia{Body: res.Body, MimeType: mimeType, Filename: media.Filename}, nil
+}
+
+// get performs an authenticated GET against the Graph API and returns the response when successful.
+func (th *MetaWhatsAppProvider) get(url string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to create HTTP request %v", err))
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", th.AccessToken))
+
+	res, err := th.HttpClient.Do(req)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("HTTP request failed %v", err))
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %s response_body %s", res.Status, string(body)))
+		return nil, fmt.Errorf("unexpected HTTP status: %s", res.Status)
+	}
+
+	return res, nil
+}
+
+// sendMessage posts a message payload to the Graph API messages endpoint of the configured phone number
+// and returns the message ID from the response.
+func (th *MetaWhatsAppProvider) sendMessage(payloadData dto.IWhatsAppMessage) (dto.SendResult, error) {
+	result := dto.SendResult{Provider: dto.PROVIDER_META, Status: dto.MESSAGE_STATUS_ACCEPTED}
+
+	requiredConfigs := []struct {
+		name  string
+		value string
+	}{
+		{"GRAPH_API_URL", th.GraphAPIURL},
+		{"GRAPH_API_VERSION", th.GraphAPIVersion},
+		{"WHATSAPP_PHONE_NUMBER_ID", th.PhoneNumberID},
+		{"WHATSAPP_ACCESS_TOKEN", th.AccessToken},
+	}
+
+	for _, configItem := range requiredConfigs {
+		if configItem.value == "" {
+			th.Logger.Error(fmt.Sprintf("%s is not set", configItem.name))
+			return result, fmt.Errorf("%s is not set", configItem.name)
+		}
+	}
+
+	payload, err := json.Marshal(payloadData)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to marshal payload %v", err))
+		return result, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/messages", th.GraphAPIURL, th.GraphAPIVersion, th.PhoneNumberID)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to create HTTP request %v", err))
+		return result, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", th.AccessToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := th.HttpClient.Do(req)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("HTTP request failed %v", err))
+		return result, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to read response body %v", err))
+		return result, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %s response_body %s", res.Status, string(body)))
+		return result, fmt.Errorf("unexpected HTTP status: %s", res.Status)
+	}
+
+	var response dto.MetaSendResponse
+	if err := json.Unmarshal(body, &response); err != nil || len(response.Messages) == 0 {
+		th.Logger.Warn(fmt.Sprintf("Message sent but no message ID in response_body %s", string(body)))
+		return result, nil
+	}
+
+	result.ProviderMessageID = response.Messages[0].ID
+	if response.Messages[0].MessageStatus != "" {
+		result.Status = response.Messages[0].MessageStatus
+	}
+
+	th.Logger.Info(fmt.Sprintf("Message %s sent successfully %s", result.ProviderMessageID, res.Status))
+	return result, nil
+}
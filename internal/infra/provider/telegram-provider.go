@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/infra/logger"
+	"strconv"
+	"strings"
+)
+
+// TelegramProvider talks to the Telegram Bot API.
+type TelegramProvider struct {
+	Logger     *logger.Logger
+	HttpClient *http.Client
+	BaseURL    string
+	BotToken   string
+}
+
+func NewTelegramProvider(logger *logger.Logger, httpClient *http.Client, config config.TelegramConfig) *TelegramProvider {
+	return &TelegramProvider{Logger: logger, HttpClient: httpClient, BaseURL: strings.TrimRight(config.APIURL, "/"), BotToken: config.BotToken}
+}
+
+// SendTextMessage sends a text message to a chat.
+func (th *TelegramProvider) SendTextMessage(chatID string, message string) (dto.SendResult, error) {
+	if chatID == "" || message == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (chat ID) and message cannot be empty")
+	}
+
+	return th.sendMessage(chatID, "sendMessage", dto.TelegramSendRequest{ChatID: chatID, Text: message})
+}
+
+// SendVoiceMessage sends an audio file by URL to a chat as a voice note. Telegram accepts
+// OGG/Opus, MP3 and M4A voice notes.
+func (th *TelegramProvider) SendVoiceMessage(chatID string, audioLink string) (dto.SendResult, error) {
+	if chatID == "" || audioLink == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (chat ID) and audio link cannot be empty")
+	}
+
+	return th.sendMessage(chatID, "sendVoice", dto.TelegramSendRequest{ChatID: chatID, Voice: audioLink})
+}
+
+// SendMediaMessage sends an image, video, document, sticker or audio file to a chat.
+//
+// Parameters:
+//   - chatID: string - The chat to send the media to.
+//   - media: dto.OutboundMedia - The media to send. Link is a public URL Telegram downloads the
+//     file from; ID is the file_id of a file Telegram already has.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Telegram.
+//   - error: Returns an error if the media is invalid or the Bot API rejects the message.
+func (th *TelegramProvider) SendMediaMessage(chatID string, media dto.OutboundMedia) (dto.SendResult, error) {
+	if err := media.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	file := media.Link
+	if file == "" {
+		file = media.ID
+	}
+
+	payloadData := dto.TelegramSendRequest{ChatID: chatID, Caption: media.Caption}
+	method := ""
+	switch media.Type {
+	case dto.OUTBOUND_MEDIA_IMAGE:
+		method, payloadData.Photo = "sendPhoto", file
+	case dto.OUTBOUND_MEDIA_VIDEO:
+		method, payloadData.Video = "sendVideo", file
+	case dto.OUTBOUND_MEDIA_DOCUMENT:
+		method, payloadData.Document = "sendDocument", file
+	case dto.OUTBOUND_MEDIA_STICKER:
+		method, payloadData.Sticker, payloadData.Caption = "sendSticker", file, ""
+	case dto.OUTBOUND_MEDIA_AUDIO:
+		method, payloadData.Audio = "sendAudio", file
+	default:
+		return dto.SendResult{}, fmt.Errorf("unsupported media type %s", media.Type)
+	}
+
+	return th.sendMessage(chatID, method, payloadData)
+}
+
+// SendLocationMessage sends a location pin to a chat.
+func (th *TelegramProvider) SendLocationMessage(chatID string, location dto.OutboundLocation) (dto.SendResult, error) {
+	if err := location.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	return th.sendMessage(chatID, "sendLocation", dto.TelegramSendRequest{ChatID: chatID, Latitude: &location.Latitude, Longitude: &location.Longitude})
+}
+
+// DownloadMedia fetches an inbound media file. Updates only carry the file_id, which getFile
+// resolves into a path under the bot's file endpoint.
+//
+// Parameters:
+//   - media: dto.InboundMedia - The media of an inbound message.
+//
+// Returns:
+//   - dto.DownloadedMedia: The open media stream and its MIME type; the caller closes the body.
+//   - error: Returns an error if the file cannot be resolved or the download fails.
+func (th *TelegramProvider) DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error) {
+	if media.ID == "" {
+		return dto.DownloadedMedia{}, fmt.Errorf("media has no file ID")
+	}
+
+	var file dto.TelegramFile
+	if err := th.call(context.Background(), "getFile", map[string]string{"file_id": media.ID}, &file); err != nil {
+		return dto.DownloadedMedia{}, err
+	}
+	if file.FilePath == "" {
+		return dto.DownloadedMedia{}, fmt.Errorf("failed to resolve file %s", media.ID)
+	}
+
+	res, err := th.HttpClient.Get(fmt.Sprintf("%s/file/bot%s/%s", th.BaseURL, th.BotToken, file.FilePath))
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("HTTP request failed %v", th.redact(err)))
+		return dto.DownloadedMedia{}, fmt.Errorf("HTTP request failed: %w", th.redact(err))
+	}
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		res.Body.Close()
+		th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %d downloading file %s", res.StatusCode, media.ID))
+		return dto.DownloadedMedia{}, fmt.Errorf("unexpected HTTP status: %d", res.StatusCode)
+	}
+
+	mimeType := media.MimeType
+	if mimeType == "" {
+		mimeType = res.Header.Get("Content-Type")
+	}
+	return dto.DownloadedMedia{Body: res.Body, MimeType: mimeType, Filename: media.Filename}, nil
+}
+
+// GetUpdates long-polls the Bot API for updates with an ID of at least offset, confirming
+// every update before it. The call returns as soon as an update arrives or after timeoutSeconds.
+func (th *TelegramProvider) GetUpdates(ctx context.Context, offset int64, timeoutSeconds int) ([]dto.TelegramUpdate, error) {
+	updates := []dto.TelegramUpdate{}
+	err := th.call(ctx, "getUpdates", map[string]interface{}{
+		"offset":          offset,
+		"timeout":         timeoutSeconds,
+		"allowed_updates": []string{"message"},
+	}, &updates)
+	return updates, err
+}
+
+// SetWebhook registers the URL Telegram posts updates to, with the secret it sends back in
+// the X-Telegram-Bot-Api-Secret-Token header.
+func (th *TelegramProvider) SetWebhook(ctx context.Context, webhookURL string, secret string) error {
+	var ok bool
+	return th.call(ctx, "setWebhook", dto.TelegramWebhookRequest{URL: webhookURL, SecretToken: secret, AllowedUpdates: []string{"message"}}, &ok)
+}
+
+// DeleteWebhook removes the webhook, which Telegram requires before getUpdates can be used.
+func (th *TelegramProvider) DeleteWebhook(ctx context.Context) error {
+	var ok bool
+	return th.call(ctx, "deleteWebhook", map[string]bool{"drop_pending_updates": false}, &ok)
+}
+
+// sendMessage calls a send method and returns the sent message ID, scoped to the chat like
+// the IDs of inbound Telegram messages.
+func (th *TelegramProvider) sendMessage(chatID string, method string, payloadData dto.TelegramSendRequest) (dto.SendResult, error) {
+	result := dto.SendResult{Provider: dto.PROVIDER_TELEGRAM, Status: dto.MESSAGE_STATUS_SENT}
+
+	var sent dto.TelegramMessage
+	if err := th.call(context.Background(), method, payloadData, &sent); err != nil {
+		return result, err
+	}
+
+	result.ProviderMessageID = chatID + ":" + strconv.FormatInt(sent.MessageID, 10)
+	th.Logger.Info(fmt.Sprintf("Message %s sent successfully", result.ProviderMessageID))
+	return result, nil
+}
+
+// call posts a JSON payload to a Bot API method and decodes the result into out.
+func (th *TelegramProvider) call(ctx context.Context, method string, payloadData interface{}, out interface{}) error {
+	if th.BotToken == "" {
+		th.Logger.Error("TELEGRAM_BOT_TOKEN is not set")
+		return fmt.Errorf("TELEGRAM_BOT_TOKEN is not set")
+	}
+
+	payload, err := json.Marshal(payloadData)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to marshal payload %v", err))
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/bot%s/%s", th.BaseURL, th.BotToken, method), bytes.NewReader(payload))
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to create HTTP request %v", th.redact(err)))
+		return fmt.Errorf("failed to create HTTP request: %w", th.redact(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := th.HttpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		th.Logger.Error(fmt.Sprintf("HTTP request failed %v", th.redact(err)))
+		return fmt.Errorf("HTTP request failed: %w", th.redact(err))
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to read response body %v", err))
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	response := dto.TelegramResponse[json.RawMessage]{}
+	if err := json.Unmarshal(body, &response); err != nil || !response.Ok {
+		th.Logger.Error(fmt.Sprintf("Telegram %s failed with HTTP status %d response_body %s", method, res.StatusCode, string(body)))
+		return fmt.Errorf("telegram %s failed: %d %s", method, res.StatusCode, response.Description)
+	}
+
+	if err := json.Unmarshal(response.Result, out); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to decode Telegram %s result %v", method, err))
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// redact removes the bot token, which is part of every Bot API URL, from transport errors.
+func (th *TelegramProvider) redact(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s Bot API: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
@@ -1,14 +1,10 @@
 package provider
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
-	"net/url"
-	"os"
 	"social-connector/internal/config"
 	"social-connector/internal/domain/dto"
 	"social-connector/internal/infra/logger"
@@ -16,12 +12,16 @@ import (
 )
 
 type InfobipWhatsAppProvider struct {
-	Logger     *logger.Logger
-	HttpClient *http.Client
+	Logger       *logger.Logger
+	HttpClient   *http.Client
+	BaseURL      string
+	From         string
+	NotifyURL    string
+	TokenManager *InfobipTokenManager
 }
 
-func NewInfobipWhatsAppProvider(logger *logger.Logger, httpClient *http.Client) *InfobipWhatsAppProvider {
-	return &InfobipWhatsAppProvider{Logger: logger, HttpClient: httpClient}
+func NewInfobipWhatsAppProvider(logger *logger.Logger, httpClient *http.Client, config config.InfobipConfig, tokenManager *InfobipTokenManager) *InfobipWhatsAppProvider {
+	return &InfobipWhatsAppProvider{Logger: logger, HttpClient: httpClient, BaseURL: config.URL, From: config.PhoneNumber, NotifyURL: config.NotifyURL, TokenManager: tokenManager}
 }
 
 // sendTextMessage sends a text message to a recipient's phone number using the Infobip API.
@@ -31,232 +31,411 @@ func NewInfobipWhatsAppProvider(logger *logger.Logger, httpClient *http.Client)
 //   - message: string - The content of the text message to be sent.
 //
 // Returns:
+//   - dto.SendResult: The message ID assigned by Infobip, used to correlate delivery and seen reports.
 //   - error: Returns an error if any step of the process fails, including input validation,
 //     payload construction, HTTP request failure, or unexpected API response.
 //
 // Dependencies:
-//   - Environment variables:
+//   - Configuration:
 //   - INFOBIP_URL: The base URL of the Infobip API.
-//   - AUTH_TOKEN: The authentication token required to authorize the request.
+//   - INFOBIP_CLIENT_ID / INFOBIP_CLIENT_SECRET: The credentials used by the token manager to authorize the request.
 //   - WHATSAPP_PHONE_NUMBER: The registered phone number used to send messages via Infobip.
 //   - Data structures: This function relies on the `dto.InfobipMessagePayload` type to create the message payload.
-func (th *InfobipWhatsAppProvider) SendTextMessage(to, message string) error {
+func (th *InfobipWhatsAppProvider) SendTextMessage(to, message string) (dto.SendResult, error) {
 	if to == "" || message == "" {
-		return fmt.Errorf("recipient (to) and message cannot be empty")
+		return dto.SendResult{}, fmt.Errorf("recipient (to) and message cannot be empty")
 	}
 
-	requiredConfigs := []struct {
-		name  string
-		value string
-	}{
-		{"INFOBIP_URL", config.GetEnv("INFOBIP_URL")},
-		{"WHATSAPP_PHONE_NUMBER", config.GetEnv("WHATSAPP_PHONE_NUMBER")},
+	payloadData := th.payload(to)
+	payloadData.Content.Text = message
+
+	return th.sendMessage("/whatsapp/1/message/text", payloadData)
+}
+
+// sendAudioMessage sends a audio message to a recipient's phone number using the Infobip API.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - audio_url: string - The content of the audio message to be sent.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Infobip, used to correlate delivery and seen reports.
+//   - error: Returns an error if any step of the process fails, including input validation,
+//     payload construction, HTTP request failure, or unexpected API response.
+//
+// Dependencies:
+//   - Configuration:
+//   - INFOBIP_URL: The base URL of the Infobip API.
+//   - INFOBIP_CLIENT_ID / INFOBIP_CLIENT_SECRET: The credentials used by the token manager to authorize the request.
+//   - WHATSAPP_PHONE_NUMBER: The registered phone number used to send messages via Infobip.
+//   - Data structures: This function relies on the `dto.InfobipMessagePayload` type to create the message payload.
+func (th *InfobipWhatsAppProvider) SendAudioMessage(to, audioLink string) (dto.SendResult, error) {
+	if to == "" || audioLink == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) and message cannot be empty")
 	}
 
-	for _, configItem := range requiredConfigs {
-		if configItem.value == "" {
-			th.Logger.Error(fmt.Sprintf("%s is not set", configItem.name))
-			return fmt.Errorf("%s is not set", configItem.name)
-		}
+	payloadData := th.payload(to)
+	payloadData.Content.MediaUrl = audioLink
+
+	return th.sendMessage("/whatsapp/1/message/audio", payloadData)
+}
+
+// SendMediaMessage sends an image, video, document, sticker or audio using the Infobip API.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - media: dto.OutboundMedia - The media type, a public link and the optional caption and filename.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Infobip, used to correlate delivery and seen reports.
+//   - error: Returns an error if the media is invalid for its type, has no public link (Infobip
+//     does not accept media IDs) or the request fails.
+func (th *InfobipWhatsAppProvider) SendMediaMessage(to string, media dto.OutboundMedia) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := media.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+	if media.Link == "" {
+		return dto.SendResult{}, fmt.Errorf("infobip needs a public link to send a %s, media IDs are not supported", media.Type)
 	}
 
-	infoBipUrl := requiredConfigs[0].value
-	from := requiredConfigs[1].value
+	payloadData := th.payload(to)
+	payloadData.Content.MediaUrl = media.Link
+	payloadData.Content.Caption = media.Caption
+	payloadData.Content.Filename = media.Filename
 
-	authToken, err := th.GenerateOAuth2Token()
-	if err != nil {
-		return err
+	return th.sendMessage(fmt.Sprintf("/whatsapp/1/message/%s", media.Type), payloadData)
+}
+
+// SendLocationMessage sends a location pin using the Infobip API.
+func (th *InfobipWhatsAppProvider) SendLocationMessage(to string, location dto.OutboundLocation) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := location.Validate(); err != nil {
+		return dto.SendResult{}, err
 	}
 
-	payloadData := struct {
-		From    string `json:"from"`
-		To      string `json:"to"`
-		Content struct {
-			Text string `json:"text"`
-		} `json:"content"`
-	}{
-		From: from,
-		To:   to,
+	payloadData := th.payload(to)
+	payloadData.Content.Latitude = &location.Latitude
+	payloadData.Content.Longitude = &location.Longitude
+	payloadData.Content.Name = location.Name
+	payloadData.Content.Address = location.Address
+
+	return th.sendMessage("/whatsapp/1/message/location", payloadData)
+}
+
+// SendContactsMessage sends one or more contact cards using the Infobip API.
+func (th *InfobipWhatsAppProvider) SendContactsMessage(to string, contacts []dto.OutboundContact) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := dto.ValidateContacts(contacts); err != nil {
+		return dto.SendResult{}, err
 	}
-	payloadData.Content.Text = message
 
-	payload, err := json.Marshal(payloadData)
-	if err != nil {
-		th.Logger.Error(fmt.Sprintf("Failed to marshal payload %v", err))
-		return fmt.Errorf("failed to marshal payload: %w", err)
+	payloadData := th.payload(to)
+	for _, contact := range contacts {
+		firstName := contact.FirstName
+		if firstName == "" {
+			firstName = contact.FormattedName
+		}
+
+		card := dto.InfobipContact{
+			Name: dto.InfobipContactName{FirstName: firstName, LastName: contact.LastName, FormattedName: contact.FormattedName},
+		}
+		if contact.Company != "" {
+			card.Org = &dto.InfobipContactOrg{Company: contact.Company}
+		}
+		for _, phone := range contact.Phones {
+			card.Phones = append(card.Phones, dto.InfobipContactPhone{Phone: phone.Phone, Type: strings.ToUpper(phone.Type), WaID: phone.WaID})
+		}
+		for _, email := range contact.Emails {
+			card.Emails = append(card.Emails, dto.InfobipContactEmail{Email: email.Email, Type: strings.ToUpper(email.Type)})
+		}
+		payloadData.Content.Contacts = append(payloadData.Content.Contacts, card)
 	}
 
-	url := fmt.Sprintf("%s/whatsapp/1/message/text", infoBipUrl)
-	req, err := http.NewRequest("POST", url, strings.NewReader(string(payload)))
-	if err != nil {
-		th.Logger.Error(fmt.Sprintf("Failed to create HTTP request %v", err))
-		return fmt.Errorf("failed to create HTTP request: %w", err)
+	return th.sendMessage("/whatsapp/1/message/contact", payloadData)
+}
+
+// SendTemplateMessage sends an approved template (HSM) using the Infobip API. Templates can
+// be sent outside the 24-hour customer service window.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - template: dto.TemplateMessage - The template name, language and the values of its header, body and button placeholders.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Infobip, used to correlate delivery and seen reports.
+//   - error: Returns an error if any step of the process fails, including input validation,
+//     payload construction, HTTP request failure, or unexpected API response.
+func (th *InfobipWhatsAppProvider) SendTemplateMessage(to string, template dto.TemplateMessage) (dto.SendResult, error) {
+	if to == "" || template.Name == "" || template.Language == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to), template name and language cannot be empty")
 	}
 
-	fmt.Println(authToken)
+	common := th.payload(to)
+	message := dto.InfobipTemplateMessage{
+		From:         common.From,
+		To:           common.To,
+		CallbackData: common.CallbackData,
+		NotifyURL:    common.NotifyURL,
+		Content: dto.InfobipTemplateContent{
+			TemplateName: template.Name,
+			Language:     template.Language,
+			TemplateData: dto.InfobipTemplateData{
+				Body: dto.InfobipTemplateBody{Placeholders: template.Body},
+			},
+		},
+	}
+	if message.Content.TemplateData.Body.Placeholders == nil {
+		message.Content.TemplateData.Body.Placeholders = []string{}
+	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", authToken.AccessToken))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	if header := template.Header; header != nil {
+		message.Content.TemplateData.Header = &dto.InfobipTemplateHeader{
+			Type:        strings.ToUpper(header.Type),
+			Placeholder: header.Text,
+			MediaURL:    header.MediaURL,
+			Filename:    header.Filename,
+		}
+	}
 
-	res, err := th.HttpClient.Do(req)
-	if err != nil {
-		th.Logger.Error(fmt.Sprintf("HTTP request failed %v", err))
-		return fmt.Errorf("HTTP request failed: %w", err)
+	for _, button := range template.Buttons {
+		message.Content.TemplateData.Buttons = append(message.Content.TemplateData.Buttons, dto.InfobipTemplateButton{
+			Type:      strings.ToUpper(button.Type),
+			Parameter: button.Parameter,
+		})
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
-		body, _ := io.ReadAll(res.Body)
-		th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %s response_body %s", res.Status, string(body)))
-		return fmt.Errorf("unexpected HTTP status: %s", res.Status)
+	return th.sendMessage("/whatsapp/1/message/template", dto.InfobipTemplatePayload{Messages: []dto.InfobipTemplateMessage{message}})
+}
+
+// SendButtonsMessage sends a message with up to three reply buttons using the Infobip API.
+//
+// Parameters:
+//   - to: string - The recipient's phone number in international format (including the country code).
+//   - message: dto.ButtonsMessage - The header, body, footer and the reply buttons.
+//
+// Returns:
+//   - dto.SendResult: The message ID assigned by Infobip, used to correlate delivery and seen reports.
+//   - error: Returns an error if the message breaks the WhatsApp limits or the request fails.
+func (th *InfobipWhatsAppProvider) SendButtonsMessage(to string, message dto.ButtonsMessage) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := message.Validate(); err != nil {
+		return dto.SendResult{}, err
 	}
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		th.Logger.Error(fmt.Sprintf("Failed to read response body %v", err))
-		return fmt.Errorf("failed to read response body: %w", err)
+	payloadData := th.interactivePayload(to, message.Header, message.Body, message.Footer)
+	payloadData.Content.Action = &dto.InfobipInteractiveAction{}
+	for _, button := range message.Buttons {
+		payloadData.Content.Action.Buttons = append(payloadData.Content.Action.Buttons, dto.InfobipInteractiveButton{Type: "REPLY", ID: button.ID, Title: button.Title})
 	}
 
-	th.Logger.Info(fmt.Sprintf("Message sent successfully %s response_body %s", res.Status, string(body)))
-	return nil
+	return th.sendMessage("/whatsapp/1/message/interactive/buttons", payloadData)
 }
 
-// sendAudioMessage sends a audio message to a recipient's phone number using the Infobip API.
+// SendListMessage sends a list message using the Infobip API.
 //
 // Parameters:
 //   - to: string - The recipient's phone number in international format (including the country code).
-//   - audio_url: string - The content of the audio message to be sent.
+//   - message: dto.ListMessage - The header, body, footer, the text of the button opening the list and its sections.
 //
 // Returns:
-//   - error: Returns an error if any step of the process fails, including input validation,
-//     payload construction, HTTP request failure, or unexpected API response.
+//   - dto.SendResult: The message ID assigned by Infobip, used to correlate delivery and seen reports.
+//   - error: Returns an error if the message breaks the WhatsApp limits or the request fails.
+func (th *InfobipWhatsAppProvider) SendListMessage(to string, message dto.ListMessage) (dto.SendResult, error) {
+	if to == "" {
+		return dto.SendResult{}, fmt.Errorf("recipient (to) cannot be empty")
+	}
+	if err := message.Validate(); err != nil {
+		return dto.SendResult{}, err
+	}
+
+	payloadData := th.interactivePayload(to, message.Header, message.Body, message.Footer)
+	payloadData.Content.Action = &dto.InfobipInteractiveAction{Title: message.ButtonText}
+	for _, section := range message.Sections {
+		payloadData.Content.Action.Sections = append(payloadData.Content.Action.Sections, dto.InfobipInteractiveSection{Title: section.Title, Rows: section.Rows})
+	}
+
+	return th.sendMessage("/whatsapp/1/message/interactive/list", payloadData)
+}
+
+func (th *InfobipWhatsAppProvider) interactivePayload(to string, header string, body string, footer string) dto.InfobipMessagePayload {
+	payloadData := th.payload(to)
+	payloadData.Content.Body = &dto.InfobipInteractiveText{Text: body}
+	if header != "" {
+		payloadData.Content.Header = &dto.InfobipInteractiveText{Type: "TEXT", Text: header}
+	}
+	if footer != "" {
+		payloadData.Content.Footer = &dto.InfobipInteractiveText{Text: footer}
+	}
+	return payloadData
+}
+
+// GenerateOAuth2Token returns a valid OAuth2 access token, reusing the cached one while it has not expired.
+func (th *InfobipWhatsAppProvider) GenerateOAuth2Token() (*dto.TokenResponse, error) {
+	return th.TokenManager.Token()
+}
+
+// DownloadMedia fetches an inbound media file from its Infobip URL, which requires the OAuth2 token.
+// When Infobip rejects the cached token with 401 the token is invalidated and the request retried once.
 //
-// Dependencies:
-//   - Environment variables:
-//   - INFOBIP_URL: The base URL of the Infobip API.
-//   - AUTH_TOKEN: The authentication token required to authorize the request.
-//   - WHATSAPP_PHONE_NUMBER: The registered phone number used to send messages via Infobip.
-//   - Data structures: This function relies on the `dto.InfobipMessagePayload` type to create the message payload.
-func (th *InfobipWhatsAppProvider) SendAudioMessage(to, audioLink string) error {
-	if to == "" || audioLink == "" {
-		return fmt.Errorf("recipient (to) and message cannot be empty")
+// Parameters:
+//   - media: dto.InboundMedia - The media of an inbound message.
+//
+// Returns:
+//   - dto.DownloadedMedia: The open media stream and its MIME type; the caller closes the body.
+//   - error: Returns an error if the media has no URL or the download fails.
+func (th *InfobipWhatsAppProvider) DownloadMedia(media dto.InboundMedia) (dto.DownloadedMedia, error) {
+	if media.URL == "" {
+		return dto.DownloadedMedia{}, fmt.Errorf("media has no URL")
+	}
+
+	for attempt := 0; ; attempt++ {
+		authToken, err := th.TokenManager.Token()
+		if err != nil {
+			return dto.DownloadedMedia{}, err
+		}
+
+		req, err := http.NewRequest("GET", media.URL, nil)
+		if err != nil {
+			th.Logger.Error(fmt.Sprintf("Failed to create HTTP request %v", err))
+			return dto.DownloadedMedia{}, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", authToken.AccessToken))
+
+		res, err := th.HttpClient.Do(req)
+		if err != nil {
+			th.Logger.Error(fmt.Sprintf("HTTP request failed %v", err))
+			return dto.DownloadedMedia{}, fmt.Errorf("HTTP request failed: %w", err)
+		}
+
+		if res.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			res.Body.Close()
+			th.Logger.Warn("Infobip rejected the OAuth2 token, refreshing and retrying")
+			th.TokenManager.Invalidate(authToken.AccessToken)
+			continue
+		}
+
+		if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+			res.Body.Close()
+			th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %d downloading media %s", res.StatusCode, media.URL))
+			return dto.DownloadedMedia{}, fmt.Errorf("unexpected HTTP status: %d", res.StatusCode)
+		}
+
+		mimeType := media.MimeType
+		if mimeType == "" {
+			mimeType = res.Header.Get("Content-Type")
+		}
+		return dto.DownloadedMedia{Body: res.Body, MimeType: mimeType, Filename: media.Filename}, nil
 	}
+}
+
+// payload builds the common part of an outbound message. When a notify URL is configured
+// Infobip posts delivery and seen reports to it, with the recipient as callback data; the
+// reports derive the conversation ID from it with dto.PhoneConversationID.
+func (th *InfobipWhatsAppProvider) payload(to string) dto.InfobipMessagePayload {
+	payloadData := dto.InfobipMessagePayload{From: th.From, To: to}
+	if th.NotifyURL != "" {
+		payloadData.NotifyURL = th.NotifyURL
+		payloadData.CallbackData = to
+	}
+	return payloadData
+}
+
+// sendMessage posts a payload to an Infobip WhatsApp endpoint and returns the message ID from the response.
+// When Infobip rejects the cached token with 401 the token is invalidated and the request retried once.
+func (th *InfobipWhatsAppProvider) sendMessage(path string, payloadData interface{}) (dto.SendResult, error) {
+	result := dto.SendResult{Provider: dto.PROVIDER_INFOBIP, Status: dto.MESSAGE_STATUS_ACCEPTED}
 
 	requiredConfigs := []struct {
 		name  string
 		value string
 	}{
-		{"INFOBIP_URL", config.GetEnv("INFOBIP_URL")},
-		{"WHATSAPP_PHONE_NUMBER", config.GetEnv("WHATSAPP_PHONE_NUMBER")},
+		{"INFOBIP_URL", th.BaseURL},
+		{"WHATSAPP_PHONE_NUMBER", th.From},
 	}
 
 	for _, configItem := range requiredConfigs {
 		if configItem.value == "" {
 			th.Logger.Error(fmt.Sprintf("%s is not set", configItem.name))
-			return fmt.Errorf("%s is not set", configItem.name)
+			return result, fmt.Errorf("%s is not set", configItem.name)
 		}
 	}
 
-	infoBipUrl := requiredConfigs[0].value
-	from := requiredConfigs[1].value
-
-	authToken, err := th.GenerateOAuth2Token()
+	payload, err := json.Marshal(payloadData)
 	if err != nil {
-		return err
+		th.Logger.Error(fmt.Sprintf("Failed to marshal payload %v", err))
+		return result, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	payloadData := struct {
-		From    string `json:"from"`
-		To      string `json:"to"`
-		Content struct {
-			MediaUrl string `json:"mediaUrl"`
-		} `json:"content"`
-	}{
-		From: from,
-		To:   to,
-	}
-	payloadData.Content.MediaUrl = audioLink
+	for attempt := 0; ; attempt++ {
+		authToken, err := th.TokenManager.Token()
+		if err != nil {
+			return result, err
+		}
 
-	payload, err := json.Marshal(payloadData)
-	if err != nil {
-		th.Logger.Error(fmt.Sprintf("Failed to marshal payload %v", err))
-		return fmt.Errorf("failed to marshal payload: %w", err)
+		status, body, err := th.post(path, payload, authToken.AccessToken)
+		if err != nil {
+			return result, err
+		}
+
+		if status == http.StatusUnauthorized && attempt == 0 {
+			th.Logger.Warn("Infobip rejected the OAuth2 token, refreshing and retrying")
+			th.TokenManager.Invalidate(authToken.AccessToken)
+			continue
+		}
+
+		if status < http.StatusOK || status >= http.StatusMultipleChoices {
+			th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %d response_body %s", status, string(body)))
+			return result, fmt.Errorf("unexpected HTTP status: %d", status)
+		}
+
+		var response dto.InfobipSendResponse
+		if err := json.Unmarshal(body, &response); err == nil && response.MessageID == "" && len(response.Messages) > 0 {
+			response = response.Messages[0]
+		}
+		if response.MessageID == "" {
+			th.Logger.Warn(fmt.Sprintf("Message sent but no message ID in response_body %s", string(body)))
+			return result, nil
+		}
+
+		result.ProviderMessageID = response.MessageID
+		th.Logger.Info(fmt.Sprintf("Message %s sent successfully %d", result.ProviderMessageID, status))
+		return result, nil
 	}
+}
 
-	url := fmt.Sprintf("%s/whatsapp/1/message/audio", infoBipUrl)
+func (th *InfobipWhatsAppProvider) post(path string, payload []byte, accessToken string) (int, []byte, error) {
+	url := fmt.Sprintf("%s%s", th.BaseURL, path)
 	req, err := http.NewRequest("POST", url, strings.NewReader(string(payload)))
 	if err != nil {
 		th.Logger.Error(fmt.Sprintf("Failed to create HTTP request %v", err))
-		return fmt.Errorf("failed to create HTTP request: %w", err)
+		return 0, nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", authToken.AccessToken))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
 	res, err := th.HttpClient.Do(req)
 	if err != nil {
 		th.Logger.Error(fmt.Sprintf("HTTP request failed %v", err))
-		return fmt.Errorf("HTTP request failed: %w", err)
+		return 0, nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
-		body, _ := io.ReadAll(res.Body)
-		th.Logger.Error(fmt.Sprintf("Unexpected HTTP status %s response_body %s", res.Status, string(body)))
-		return fmt.Errorf("unexpected HTTP status: %s", res.Status)
-	}
-
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
 		th.Logger.Error(fmt.Sprintf("Failed to read response body %v", err))
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	th.Logger.Info(fmt.Sprintf("Message sent successfully %s response_body %s", res.Status, string(body)))
-	return nil
-}
-
-func (th *InfobipWhatsAppProvider) GenerateOAuth2Token() (*dto.TokenResponse, error) {
-	infobipUrl := config.GetEnv("INFOBIP_URL")
-	apiURL := fmt.Sprintf("%s/auth/1/oauth2/token", infobipUrl)
-
-	clientID := os.Getenv("INFOBIP_CLIENT_ID")
-	clientSecret := os.Getenv("INFOBIP_CLIENT_SECRET")
-	if clientID == "" || clientSecret == "" {
-		log.Fatal("Environment variables INFOBIP_CLIENT_ID and INFOBIP_CLIENT_SECRET must be set.")
-	}
-
-	data := url.Values{}
-	data.Set("client_id", clientID)
-	data.Set("client_secret", clientSecret)
-	data.Set("grant_type", "client_credentials")
-
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBufferString(data.Encode()))
-	if err != nil {
-		return &dto.TokenResponse{}, fmt.Errorf("failed to create HTTP request: %v", err)
-	}
-
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Add("Accept", "application/json")
-
-	resp, err := th.HttpClient.Do(req)
-	if err != nil {
-		return &dto.TokenResponse{}, fmt.Errorf("failed to send request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return &dto.TokenResponse{}, fmt.Errorf("unexpected HTTP status: %d, response: %s", resp.StatusCode, string(body))
-	}
-
-	var tokenResponse dto.TokenResponse
-	err = json.NewDecoder(resp.Body).Decode(&tokenResponse)
-	if err != nil {
-		return &dto.TokenResponse{}, fmt.Errorf("error decoding response JSON: %v", err)
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return &tokenResponse, nil
+	return res.StatusCode, body, nil
 }
@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-connector/internal/domain/entities"
+	repocontants "social-connector/internal/domain/interfaces/repository/contants"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// claimCandidates bounds how many queued items are inspected per claim when
+// looking for a conversation that is not locked by another worker.
+const claimCandidates = 50
+
+// arrivalOrder sorts queued items by arrival. The _id breaks ties between items stored
+// within the same millisecond.
+var arrivalOrder = bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+
+type MongoInboundQueueRepository struct {
+	mongo *mongo.Database
+}
+
+func NewMongoInboundQueueRepository(mongo *mongo.Database) *MongoInboundQueueRepository {
+	return &MongoInboundQueueRepository{mongo: mongo}
+}
+
+func (r *MongoInboundQueueRepository) queue() *mongo.Collection {
+	return r.mongo.Collection(repocontants.INBOUND_QUEUE_COLLECTION)
+}
+
+func (r *MongoInboundQueueRepository) locks() *mongo.Collection {
+	return r.mongo.Collection(repocontants.CONVERSATION_LOCK_COLLECTION)
+}
+
+func (r *MongoInboundQueueRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.queue().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "available_at", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "lease_until", Value: 1}}},
+		{Keys: bson.D{{Key: "conversation_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+	})
+	return err
+}
+
+func (r *MongoInboundQueueRepository) Enqueue(ctx context.Context, items []entities.InboundQueueItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(items))
+	for i, item := range items {
+		documents[i] = item
+	}
+
+	_, err := r.queue().InsertMany(ctx, documents)
+	return err
+}
+
+// ClaimNext leases the next item to the given owner while keeping per-conversation order.
+//
+// The owner first acquires the lock of a conversation with claimable work and then leases the
+// oldest item of that conversation. If that item is still waiting for a retry backoff, the lock
+// is released and the conversation is skipped, so newer messages never overtake older ones.
+// Items whose lease expired are eligible again, so work abandoned by a crashed worker is picked up.
+// Returns nil when there is nothing to claim.
+func (r *MongoInboundQueueRepository) ClaimNext(ctx context.Context, owner string, lease time.Duration) (*entities.InboundQueueItem, error) {
+	now := time.Now()
+	claimable := bson.M{
+		"$or": bson.A{
+			bson.M{"status": entities.QUEUE_STATUS_PENDING, "available_at": bson.M{"$lte": now}},
+			bson.M{"status": entities.QUEUE_STATUS_PROCESSING, "lease_until": bson.M{"$lt": now}},
+		},
+	}
+
+	opts := options.Find().
+		SetSort(arrivalOrder).
+		SetProjection(bson.M{"conversation_id": 1}).
+		SetLimit(claimCandidates)
+	cursor, err := r.queue().Find(ctx, claimable, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []entities.InboundQueueItem
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, err
+	}
+
+	visited := map[string]bool{}
+	for _, candidate := range candidates {
+		if visited[candidate.ConversationID] {
+			continue
+		}
+		visited[candidate.ConversationID] = true
+
+		acquired, err := r.acquireLock(ctx, candidate.ConversationID, owner, lease)
+		if err != nil {
+			return nil, err
+		}
+		if !acquired {
+			continue
+		}
+
+		item, err := r.claimOldest(ctx, candidate.ConversationID, owner, lease)
+		if err != nil || item == nil {
+			r.releaseLock(ctx, candidate.ConversationID, owner)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		return item, nil
+	}
+
+	return nil, nil
+}
+
+// claimOldest leases the oldest item of a conversation, provided it is claimable right now.
+func (r *MongoInboundQueueRepository) claimOldest(ctx context.Context, conversationID string, owner string, lease time.Duration) (*entities.InboundQueueItem, error) {
+	var oldest entities.InboundQueueItem
+	err := r.queue().FindOne(ctx,
+		bson.M{"conversation_id": conversationID},
+		options.FindOne().SetSort(arrivalOrder),
+	).Decode(&oldest)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	filter := bson.M{
+		"_id": oldest.ID,
+		"$or": bson.A{
+			bson.M{"status": entities.QUEUE_STATUS_PENDING, "available_at": bson.M{"$lte": now}},
+			bson.M{"status": entities.QUEUE_STATUS_PROCESSING, "lease_until": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":      entities.QUEUE_STATUS_PROCESSING,
+			"lease_owner": owner,
+			"lease_until": now.Add(lease),
+			"updated_at":  now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+
+	var item entities.InboundQueueItem
+	err = r.queue().FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// acquireLock takes the conversation lock when it is free, expired or already held by owner.
+// A concurrent holder makes the upsert collide on _id, which is reported as not acquired.
+func (r *MongoInboundQueueRepository) acquireLock(ctx context.Context, conversationID string, owner string, lease time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": conversationID,
+		"$or": bson.A{
+			bson.M{"lease_until": bson.M{"$lt": now}},
+			bson.M{"owner": owner},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": owner, "lease_until": now.Add(lease)}}
+
+	_, err := r.locks().UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *MongoInboundQueueRepository) releaseLock(ctx context.Context, conversationID string, owner string) error {
+	_, err := r.locks().DeleteOne(ctx, bson.M{"_id": conversationID, "owner": owner})
+	return err
+}
+
+func (r *MongoInboundQueueRepository) ExtendLease(ctx context.Context, item entities.InboundQueueItem, owner string, lease time.Duration) error {
+	leaseUntil := time.Now().Add(lease)
+
+	filter := bson.M{"_id": item.ID, "lease_owner": owner, "status": entities.QUEUE_STATUS_PROCESSING}
+	update := bson.M{"$set": bson.M{"lease_until": leaseUntil, "updated_at": time.Now()}}
+	result, err := r.queue().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("lease for item %s is no longer held by %s", item.ID.Hex(), owner)
+	}
+
+	_, err = r.locks().UpdateOne(ctx,
+		bson.M{"_id": item.ConversationID, "owner": owner},
+		bson.M{"$set": bson.M{"lease_until": leaseUntil}},
+	)
+	return err
+}
+
+func (r *MongoInboundQueueRepository) Complete(ctx context.Context, item entities.InboundQueueItem, owner string) error {
+	if _, err := r.queue().DeleteOne(ctx, bson.M{"_id": item.ID, "lease_owner": owner}); err != nil {
+		return err
+	}
+	return r.releaseLock(ctx, item.ConversationID, owner)
+}
+
+func (r *MongoInboundQueueRepository) Retry(ctx context.Context, item entities.InboundQueueItem, owner string, availableAt time.Time, lastError string) error {
+	filter := bson.M{"_id": item.ID, "lease_owner": owner}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       entities.QUEUE_STATUS_PENDING,
+			"available_at": availableAt,
+			"last_error":   lastError,
+			"updated_at":   time.Now(),
+		},
+		"$unset": bson.M{"lease_owner": "", "lease_until": ""},
+	}
+
+	if _, err := r.queue().UpdateOne(ctx, filter, update); err != nil {
+		return err
+	}
+	return r.releaseLock(ctx, item.ConversationID, owner)
+}
+
+// DeadLetter moves an item that exhausted its attempts into the dead-letter collection.
+func (r *MongoInboundQueueRepository) DeadLetter(ctx context.Context, item entities.InboundQueueItem, owner string, lastError string) error {
+	deadLetter := entities.DeadLetterItem{
+		ID:             item.ID,
+		ConversationID: item.ConversationID,
+		Message:        item.Message,
+		Attempts:       item.Attempts,
+		LastError:      lastError,
+		CreatedAt:      item.CreatedAt,
+		FailedAt:       time.Now(),
+	}
+
+	_, err := r.mongo.Collection(repocontants.INBOUND_DEAD_LETTER_COLLECTION).InsertOne(ctx, deadLetter)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	if _, err := r.queue().DeleteOne(ctx, bson.M{"_id": item.ID}); err != nil {
+		return err
+	}
+	return r.releaseLock(ctx, item.ConversationID, owner)
+}
+
+// RequeueExpired returns every item whose lease expired back to the pending state
+// and drops conversation locks left behind by stopped workers.
+func (r *MongoInboundQueueRepository) RequeueExpired(ctx context.Context) (int64, error) {
+	now := time.Now()
+	filter := bson.M{"status": entities.QUEUE_STATUS_PROCESSING, "lease_until": bson.M{"$lt": now}}
+	update := bson.M{
+		"$set":   bson.M{"status": entities.QUEUE_STATUS_PENDING, "available_at": now, "updated_at": now},
+		"$unset": bson.M{"lease_owner": "", "lease_until": ""},
+	}
+
+	result, err := r.queue().UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := r.locks().DeleteMany(ctx, bson.M{"lease_until": bson.M{"$lt": now}}); err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"social-connector/internal/domain/entities"
+	repocontants "social-connector/internal/domain/interfaces/repository/contants"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoMediaUploadRepository struct {
+	mongo *mongo.Database
+}
+
+func NewMongoMediaUploadRepository(mongo *mongo.Database) *MongoMediaUploadRepository {
+	return &MongoMediaUploadRepository{mongo: mongo}
+}
+
+func (r *MongoMediaUploadRepository) collection() *mongo.Collection {
+	return r.mongo.Collection(repocontants.MEDIA_UPLOAD_COLLECTION)
+}
+
+// EnsureIndexes creates the TTL index that drops cached uploads at their expiry.
+func (r *MongoMediaUploadRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func (r *MongoMediaUploadRepository) Find(ctx context.Context, id string) (entities.MediaUpload, error) {
+	var upload entities.MediaUpload
+	err := r.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&upload)
+	return upload, err
+}
+
+func (r *MongoMediaUploadRepository) Save(ctx context.Context, upload entities.MediaUpload) error {
+	_, err := r.collection().ReplaceOne(ctx, bson.M{"_id": upload.ID}, upload, options.Replace().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"social-connector/internal/domain/entities"
+	repocontants "social-connector/internal/domain/interfaces/repository/contants"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoMediaRepository struct {
+	mongo *mongo.Database
+}
+
+func NewMongoMediaRepository(mongo *mongo.Database) *MongoMediaRepository {
+	return &MongoMediaRepository{mongo: mongo}
+}
+
+func (r *MongoMediaRepository) collection() *mongo.Collection {
+	return r.mongo.Collection(repocontants.MEDIA_COLLECTION)
+}
+
+func (r *MongoMediaRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "provider_media_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "conversation_id", Value: 1}}},
+	})
+	return err
+}
+
+// Create stores the media record. When the same provider media was stored concurrently
+// the existing record is returned instead.
+func (r *MongoMediaRepository) Create(ctx context.Context, media entities.StoredMedia) (entities.StoredMedia, error) {
+	result, err := r.collection().InsertOne(ctx, media)
+	if mongo.IsDuplicateKeyError(err) {
+		return r.FindByProviderMediaID(ctx, media.Provider, media.ProviderMediaID)
+	}
+	if err != nil {
+		return entities.StoredMedia{}, err
+	}
+
+	media.ID = result.InsertedID.(primitive.ObjectID)
+	return media, nil
+}
+
+func (r *MongoMediaRepository) FindByID(ctx context.Context, id string) (entities.StoredMedia, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entities.StoredMedia{}, mongo.ErrNoDocuments
+	}
+
+	var media entities.StoredMedia
+	err = r.collection().FindOne(ctx, bson.M{"_id": objectID}).Decode(&media)
+	return media, err
+}
+
+func (r *MongoMediaRepository) FindByProviderMediaID(ctx context.Context, provider string, providerMediaID string) (entities.StoredMedia, error) {
+	var media entities.StoredMedia
+	err := r.collection().FindOne(ctx, bson.M{"provider": provider, "provider_media_id": providerMediaID}).Decode(&media)
+	return media, err
+}
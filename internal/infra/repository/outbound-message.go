@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+	repocontants "social-connector/internal/domain/interfaces/repository/contants"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoOutboundMessageRepository struct {
+	mongo *mongo.Database
+}
+
+func NewMongoOutboundMessageRepository(mongo *mongo.Database) *MongoOutboundMessageRepository {
+	return &MongoOutboundMessageRepository{mongo: mongo}
+}
+
+func (r *MongoOutboundMessageRepository) collection() *mongo.Collection {
+	return r.mongo.Collection(repocontants.OUTBOUND_MESSAGE_COLLECTION)
+}
+
+func (r *MongoOutboundMessageRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "provider_message_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "conversation_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	return err
+}
+
+// SaveSent stores a message accepted by the provider. A status notification can arrive
+// before the send returns, so the record is merged with any existing one instead of
+// replacing its status.
+func (r *MongoOutboundMessageRepository) SaveSent(ctx context.Context, message entities.OutboundMessage) (entities.OutboundMessage, error) {
+	now := time.Now()
+	filter := bson.M{"provider": message.Provider, "provider_message_id": message.ProviderMessageID}
+
+	update := bson.M{
+		"$set": bson.M{
+			"conversation_id": message.ConversationID,
+			"recipient":       message.Recipient,
+			"payload_type":    message.PayloadType,
+			"content":         message.Content,
+			"sent_at":         now,
+			"updated_at":      now,
+		},
+		"$setOnInsert": bson.M{"status": message.Status},
+		"$min":         bson.M{"created_at": now},
+		"$push": bson.M{"status_history": entities.OutboundStatus{
+			Status:    message.Status,
+			Timestamp: now,
+		}},
+	}
+	if message.ProviderIDMissing {
+		update["$setOnInsert"] = bson.M{"status": message.Status, "provider_id_missing": true}
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var entity entities.OutboundMessage
+	err := r.collection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&entity)
+	return entity, err
+}
+
+// AppendStatus records a status event against the outbound message, creating the record
+// when the status arrives before the message was stored. The current status is only
+// replaced when advance is true.
+func (r *MongoOutboundMessageRepository) AppendStatus(ctx context.Context, event dto.MessageStatusEvent, advance bool) (entities.OutboundMessage, error) {
+	now := time.Now()
+	filter := bson.M{"provider": event.Provider, "provider_message_id": event.ProviderMessageID}
+
+	set := bson.M{"updated_at": now}
+	if advance {
+		set["status"] = event.Status
+	}
+	if len(event.Errors) > 0 {
+		set["errors"] = event.Errors
+	}
+	if event.Pricing != nil {
+		set["pricing"] = event.Pricing
+	}
+	if event.Conversation != nil {
+		set["conversation"] = event.Conversation
+	}
+
+	setOnInsert := bson.M{
+		"conversation_id": event.ConversationID,
+		"recipient":       event.Recipient,
+		"created_at":      now,
+	}
+	if !advance {
+		setOnInsert["status"] = event.Status
+	}
+
+	update := bson.M{
+		"$set":         set,
+		"$setOnInsert": setOnInsert,
+		"$push": bson.M{"status_history": entities.OutboundStatus{
+			Status:    event.Status,
+			Timestamp: event.Timestamp,
+			Errors:    event.Errors,
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var entity entities.OutboundMessage
+	err := r.collection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&entity)
+	return entity, err
+}
+
+func (r *MongoOutboundMessageRepository) FindByProviderMessageID(ctx context.Context, provider string, providerMessageID string) (entities.OutboundMessage, error) {
+	var entity entities.OutboundMessage
+	filter := bson.M{"provider": provider, "provider_message_id": providerMessageID}
+	err := r.collection().FindOne(ctx, filter).Decode(&entity)
+	return entity, err
+}
+
+func (r *MongoOutboundMessageRepository) FindByConversationID(ctx context.Context, conversationID string) ([]entities.OutboundMessage, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection().Find(ctx, bson.M{"conversation_id": conversationID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := []entities.OutboundMessage{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"social-connector/internal/domain/entities"
+	repocontants "social-connector/internal/domain/interfaces/repository/contants"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoProcessedMessageRepository struct {
+	mongo *mongo.Database
+}
+
+func NewMongoProcessedMessageRepository(mongo *mongo.Database) *MongoProcessedMessageRepository {
+	return &MongoProcessedMessageRepository{mongo: mongo}
+}
+
+func (r *MongoProcessedMessageRepository) collection() *mongo.Collection {
+	return r.mongo.Collection(repocontants.PROCESSED_MESSAGE_COLLECTION)
+}
+
+// EnsureIndexes creates the TTL index that expires dedup records after ttl.
+func (r *MongoProcessedMessageRepository) EnsureIndexes(ctx context.Context, ttl time.Duration) error {
+	_, err := r.collection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(ttl.Seconds())),
+	})
+	return err
+}
+
+// Register stores the message marker and reports whether it was new.
+// A duplicate key means the message was already registered.
+func (r *MongoProcessedMessageRepository) Register(ctx context.Context, entity entities.ProcessedMessage) (bool, error) {
+	_, err := r.collection().InsertOne(ctx, entity)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *MongoProcessedMessageRepository) Remove(ctx context.Context, id string) error {
+	_, err := r.collection().DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
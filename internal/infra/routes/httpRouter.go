@@ -9,13 +9,25 @@ import (
 )
 
 type Routes struct {
-	Mux            *mux.Router
-	HttpHandler    *handlers.HttpHandlers
-	InfobipHandler *handlers.InfobipHandlers
+	Mux                  *mux.Router
+	HttpHandler          *handlers.HttpHandlers
+	InfobipHandler       *handlers.InfobipHandlers
+	MessageStatusHandler *handlers.MessageStatusHandlers
+	TemplateHandler      *handlers.TemplateHandlers
+	MediaHandler         *handlers.MediaHandlers
+	ChannelHandler       *handlers.ChannelHandlers
+	TelegramHandler      *handlers.TelegramHandlers
+	WebChatHandler       *handlers.WebChatHandlers
+	StatsHandler         *handlers.StatsHandlers
+	MetaSignature        mux.MiddlewareFunc
+	InfobipAuth          mux.MiddlewareFunc
+	TelegramSecret       mux.MiddlewareFunc
+	WebChatCORS          mux.MiddlewareFunc
+	APIKey               mux.MiddlewareFunc
 }
 
-func NewRoutes(mux *mux.Router, HttpHandler *handlers.HttpHandlers, InfobipHandler *handlers.InfobipHandlers) *Routes {
-	return &Routes{mux, HttpHandler, InfobipHandler}
+func NewRoutes(mux *mux.Router, HttpHandler *handlers.HttpHandlers, InfobipHandler *handlers.InfobipHandlers, MessageStatusHandler *handlers.MessageStatusHandlers, TemplateHandler *handlers.TemplateHandlers, MediaHandler *handlers.MediaHandlers, ChannelHandler *handlers.ChannelHandlers, TelegramHandler *handlers.TelegramHandlers, WebChatHandler *handlers.WebChatHandlers, StatsHandler *handlers.StatsHandlers, MetaSignature mux.MiddlewareFunc, InfobipAuth mux.MiddlewareFunc, TelegramSecret mux.MiddlewareFunc, WebChatCORS mux.MiddlewareFunc, APIKey mux.MiddlewareFunc) *Routes {
+	return &Routes{mux, HttpHandler, InfobipHandler, MessageStatusHandler, TemplateHandler, MediaHandler, ChannelHandler, TelegramHandler, WebChatHandler, StatsHandler, MetaSignature, InfobipAuth, TelegramSecret, WebChatCORS, APIKey}
 }
 
 // Estruturas para processar o JSON recebido
@@ -29,8 +41,34 @@ type WebhookRequest struct {
 }
 
 func (r *Routes) Init() {
-	r.Mux.HandleFunc("/webhook", r.HttpHandler.MetaWebhook)
-	r.Mux.HandleFunc("/infobip-webhook", r.InfobipHandler.InfoBipWebhook)
+	r.Mux.Handle("/webhook", r.MetaSignature(http.HandlerFunc(r.HttpHandler.MetaWebhook)))
+	r.Mux.Handle("/infobip-webhook", r.InfobipAuth(http.HandlerFunc(r.InfobipHandler.InfoBipWebhook)))
+	r.Mux.HandleFunc("/media/{id}", r.MediaHandler.GetSignedMedia).Methods(http.MethodGet, http.MethodHead)
+	r.Mux.Handle("/infobip-webhook/reports", r.InfobipAuth(http.HandlerFunc(r.InfobipHandler.InfobipReportsWebhook)))
+	r.Mux.Handle("/telegram-webhook", r.TelegramSecret(http.HandlerFunc(r.TelegramHandler.TelegramWebhook)))
+
+	// The web chat is only served when enabled.
+	if r.WebChatHandler != nil {
+		webChat := r.Mux.PathPrefix("/webchat").Subrouter()
+		webChat.Use(r.WebChatCORS)
+		webChat.HandleFunc("/widget.js", r.WebChatHandler.Widget).Methods(http.MethodGet)
+		webChat.HandleFunc("/sessions", r.WebChatHandler.CreateSession).Methods(http.MethodPost, http.MethodOptions)
+		webChat.HandleFunc("/messages", r.WebChatHandler.PostMessage).Methods(http.MethodPost, http.MethodOptions)
+		webChat.HandleFunc("/history", r.WebChatHandler.History).Methods(http.MethodGet, http.MethodOptions)
+		webChat.HandleFunc("/events", r.WebChatHandler.Events).Methods(http.MethodGet)
+		webChat.HandleFunc("/ws", r.WebChatHandler.Socket).Methods(http.MethodGet)
+	}
+
+	api := r.Mux.PathPrefix("/api").Subrouter()
+	api.Use(r.APIKey)
+	api.HandleFunc("/messages/{provider}/{id}", r.MessageStatusHandler.GetMessage).Methods(http.MethodGet)
+	api.HandleFunc("/conversations/{conversationId}/messages", r.MessageStatusHandler.ListConversationMessages).Methods(http.MethodGet)
+	api.HandleFunc("/media/{id}", r.MediaHandler.GetMedia).Methods(http.MethodGet, http.MethodHead)
+	api.HandleFunc("/templates", r.TemplateHandler.ListTemplates).Methods(http.MethodGet)
+	api.HandleFunc("/templates/{name}", r.TemplateHandler.GetTemplate).Methods(http.MethodGet)
+	api.HandleFunc("/templates/send", r.TemplateHandler.SendTemplate).Methods(http.MethodPost)
+	api.HandleFunc("/channels", r.ChannelHandler.ListChannels).Methods(http.MethodGet)
+	api.HandleFunc("/stats", r.StatsHandler.GetStats).Methods(http.MethodGet)
 
 	r.Mux.HandleFunc("/healthCheck", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
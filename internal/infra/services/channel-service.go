@@ -1,88 +1,182 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"social-connector/internal/config"
 	"social-connector/internal/domain/dto"
 	"social-connector/internal/domain/entities"
 	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/channel"
 	"social-connector/internal/infra/logger"
-	"social-connector/internal/infra/provider"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
+// ErrNotRetryable marks failures that happen after a reply already reached the user.
+// Processing the message again would send duplicate replies, so the queue dead-letters it instead.
+var ErrNotRetryable = errors.New("not retryable")
+
 type ChannelService struct {
-	Logger             *logger.Logger
-	UserContextService Iservices.IUserContextService
-	QueryAIService     Iservices.IQueryAIService
-	WhatsAppProvider   provider.IWhatsAppProvider
+	Logger               *logger.Logger
+	UserContextService   Iservices.IUserContextService
+	QueryAIService       Iservices.IQueryAIService
+	MessageStatusService Iservices.IMessageStatusService
+	MediaService         Iservices.IMediaService
+	Channels             *channel.Registry
+	UnsupportedReply     string
+	OptionsButtonText    string
+	RehostAIAudio        bool
+}
+
+func NewChannelService(logger *logger.Logger, userContextService Iservices.IUserContextService, queryAIService Iservices.IQueryAIService, messageStatusService Iservices.IMessageStatusService, mediaService Iservices.IMediaService, channels *channel.Registry, messages config.MessagesConfig, media config.MediaConfig) *ChannelService {
+	return &ChannelService{
+		Logger:               logger,
+		UserContextService:   userContextService,
+		QueryAIService:       queryAIService,
+		MessageStatusService: messageStatusService,
+		MediaService:         mediaService,
+		Channels:             channels,
+		UnsupportedReply:     messages.UnsupportedReply,
+		OptionsButtonText:    messages.OptionsButtonText,
+		RehostAIAudio:        media.RehostAIAudio,
+	}
+}
+
+// ProcessInbound runs a normalized inbound message through the conversation pipeline.
+//
+// The pipeline loads (or initializes) the user context, queries the AI service according
+// to the message type, persists the updated transcript and replies through the channel
+// the message was received from.
+//
+// Parameters:
+//   - message: dto.InboundMessage - The normalized message produced by one of the webhook handlers.
+//
+// Returns:
+//   - error: Returns an error if the channel is unknown or any step of the pipeline fails.
+func (th *ChannelService) ProcessInbound(message dto.InboundMessage) error {
+	replyChannel, err := th.Channels.ResolveMessage(message)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to resolve channel of conversation %s: %v", message.ConversationID, err))
+		return err
+	}
+
+	conversationalId := message.ConversationID
+	th.Logger.Info(fmt.Sprintf("Conversation ID: %s, Provider: %s, Type: %s", conversationalId, message.Provider, message.Type))
+
+	if message.Type == dto.INBOUND_MESSAGE_REACTION {
+		if message.Reaction != nil {
+			th.Logger.Info(fmt.Sprintf("Reaction %s to message %s in conversation %s", message.Reaction.Emoji, message.Reaction.MessageID, conversationalId))
+		}
+		return nil
+	}
+
+	if message.Media != nil {
+		media, err := th.MediaService.StoreInbound(message)
+		if err != nil {
+			th.Logger.Error(fmt.Sprintf("Failed to store media of message %s: %v", message.ID, err))
+			return err
+		}
+		message.MediaURL = th.MediaService.URL(media)
+		message.StoredMediaID = media.ID.Hex()
+	}
+
+	userContext, err := th.UserContextService.FindContext(conversationalId)
+	if err != nil {
+		userContext, err = th.adoptLegacyContext(message)
+	}
+	if err != nil {
+		th.Logger.Warn(fmt.Sprintf("Context not found for conversation ID %s. Initializing new context.", conversationalId))
+		userContext = entities.UserContext{
+			ConversationID: conversationalId,
+			Transcript:     []entities.Transcript{},
+			Context:        "",
+		}
+
+		err := th.UserContextService.Create(userContext)
+		if err != nil {
+			th.Logger.Error(fmt.Sprintf("Error to create a new context to %s. Err: %v", conversationalId, err))
+		}
+	}
+
+	switch message.Type {
+	case dto.INBOUND_MESSAGE_TEXT, dto.INBOUND_MESSAGE_BUTTON_REPLY, dto.INBOUND_MESSAGE_LIST_REPLY:
+		return th.processText(replyChannel, message, userContext)
+	case dto.INBOUND_MESSAGE_AUDIO:
+		if message.MediaURL == "" {
+			return th.replyUnsupported(replyChannel, message)
+		}
+		return th.processAudio(replyChannel, message, userContext)
+	default:
+		return th.replyUnsupported(replyChannel, message)
+	}
 }
 
-func NewChannelService(logger *logger.Logger, userContextService Iservices.IUserContextService, queryAIService Iservices.IQueryAIService, whatsAppProvider provider.IWhatsAppProvider) *ChannelService {
-	return &ChannelService{Logger: logger, UserContextService: userContextService, QueryAIService: queryAIService, WhatsAppProvider: whatsAppProvider}
+// adoptLegacyContext moves the context stored under the legacy conversation ID of a WhatsApp
+// number (see dto.LegacyConversationID) to its current conversation ID, so conversations
+// started before numbers were normalized keep their transcript.
+func (th *ChannelService) adoptLegacyContext(message dto.InboundMessage) (entities.UserContext, error) {
+	legacyID := dto.LegacyConversationID(message)
+	if legacyID == "" {
+		return entities.UserContext{}, fmt.Errorf("conversation %s has no legacy ID", message.ConversationID)
+	}
+
+	userContext, err := th.UserContextService.FindContext(legacyID)
+	if err != nil {
+		return entities.UserContext{}, err
+	}
+
+	userContext.ConversationID = message.ConversationID
+	if _, err := th.UserContextService.UpdateUserContext(legacyID, userContext); err != nil {
+		return entities.UserContext{}, err
+	}
+
+	th.Logger.Info(fmt.Sprintf("Moved context of conversation %s to %s", legacyID, message.ConversationID))
+	return userContext, nil
 }
 
-func (th *ChannelService) WebhookService(webhookDto *dto.InboundResponse) {
-	// messageType := webhookDto.Results[webhookDto.MessageCount-1].Message.Type
-	// lastMessage := webhookDto.Results[webhookDto.MessageCount-1].Message.Text
-	// userAudioUrl := webhookDto.Results[webhookDto.MessageCount-1].Message.Url
-	to := webhookDto.Results[webhookDto.MessageCount-1].From
-
-	messagesSplit := []string{
-		"Olá! Chegamos ao fim da minha versão beta inicial e agora farei uma pausa para manutenção e melhorias",
-		"Agradeço pela compreensão. 😊",
-	}
-
-	for i, message := range messagesSplit {
-		if strings.TrimSpace(message) != "" {
-			if i > 0 {
-				time.Sleep(2 * time.Second)
-			}
-			if err := th.WhatsAppProvider.SendTextMessage(to, message); err != nil {
-				th.Logger.Error(fmt.Sprintf("Failed to send WhatsApp message to %s: %s", to, err.Error()))
-				return
-			}
-		}
-	}
-
-	return
-
-	// userContext, err := th.UserContextService.FindContext(to)
-	// if err != nil {
-	// 	th.Logger.Warn(fmt.Sprintf("Context not found for conversation ID %s. Initializing new context.", to))
-	// 	userContext = entities.UserContext{
-	// 		ConversationID: to,
-	// 		Transcript:     []entities.Transcript{},
-	// 		Context:        "",
-	// 	}
-
-	// 	err := th.UserContextService.Create(userContext)
-	// 	if err != nil {
-	// 		th.Logger.Error(fmt.Sprintf("Error to create a new context to %s. Err: %v", to, err))
-	// 	}
-	// }
-
-	// switch messageType {
-	// case "TEXT":
-	// 	th.processText(lastMessage, userContext, to)
-	// case "AUDIO":
-	// 	th.processAudio(userAudioUrl, userContext, to)
-	// default:
-	// 	th.Logger.Warn("Unavailable message type")
-	// }
+// replyUnsupported answers message types the pipeline cannot handle with the configured polite reply.
+func (th *ChannelService) replyUnsupported(replyChannel channel.IChannel, message dto.InboundMessage) error {
+	th.Logger.Warn(fmt.Sprintf("Unavailable message type %s in conversation %s", message.Type, message.ConversationID))
+
+	if th.UnsupportedReply == "" {
+		return nil
+	}
+
+	return th.send(replyChannel, message, dto.ChannelMessage{Type: dto.OUTBOUND_PAYLOAD_TEXT, Text: th.UnsupportedReply})
 }
 
-func (cs *ChannelService) processText(lastMessage string, userContext entities.UserContext, to string) {
+// send delivers a reply through the channel and records it in the outbound message log.
+func (th *ChannelService) send(replyChannel channel.IChannel, message dto.InboundMessage, reply dto.ChannelMessage) error {
+	result, err := replyChannel.Send(message.ReplyTo, reply)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to send %s message to %s through %s: %v", reply.Type, message.ReplyTo, replyChannel.Name(), err))
+		return err
+	}
+	th.recordSent(message, reply.Type, reply.Summary(), result)
+	return nil
+}
+
+// recordSent stores a sent reply in the outbound message log. The reply already left, so
+// a failure is only logged instead of failing (and retrying) the inbound message.
+func (th *ChannelService) recordSent(message dto.InboundMessage, payloadType string, content string, result dto.SendResult) {
+	if _, err := th.MessageStatusService.RecordSent(message.ConversationID, message.ReplyTo, payloadType, content, result); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to record outbound message to %s: %v", message.ReplyTo, err))
+	}
+}
+
+func (cs *ChannelService) processText(replyChannel channel.IChannel, message dto.InboundMessage, userContext entities.UserContext) error {
 	userContext.Transcript = append(userContext.Transcript, entities.Transcript{
 		Role:      "user",
-		Message:   lastMessage,
+		Message:   message.Text,
 		Timestamp: time.Now(),
 	})
 
-	result, err := cs.QueryAIService.ExecuteQueryAI(lastMessage, userContext.Context)
+	result, err := cs.QueryAIService.ExecuteQueryAI(message.Text, userContext.Context)
 	if err != nil {
 		cs.Logger.Error(fmt.Sprintf("Failed to execute AI query: %s", err.Error()))
-		return
+		return err
 	}
 
 	userContext.Transcript = append(userContext.Transcript, entities.Transcript{
@@ -98,36 +192,96 @@ func (cs *ChannelService) processText(lastMessage string, userContext entities.U
 		userContext.Context = result.Response
 	}
 
+	to := message.ReplyTo
+	chunks := []string{}
+	for _, chunk := range strings.Split(result.Response, ".") {
+		if strings.TrimSpace(chunk) != "" {
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	cs.Logger.Info(fmt.Sprintf("Sending AI response messages to %s through %s", to, replyChannel.Name()))
+	for i, chunk := range chunks {
+		if i > 0 {
+			time.Sleep(2 * time.Second)
+		}
+
+		var err error
+		if i == len(chunks)-1 && len(result.Options) > 0 {
+			err = cs.sendOptions(replyChannel, message, chunk, result.Options)
+		} else {
+			err = cs.send(replyChannel, message, dto.ChannelMessage{Type: dto.OUTBOUND_PAYLOAD_TEXT, Text: chunk})
+		}
+		if err == nil {
+			continue
+		}
+
+		if i == 0 {
+			return err
+		}
+
+		// Part of the answer was delivered: keep the turn and give up instead of answering twice.
+		cs.saveContext(message.ConversationID, userContext)
+		return fmt.Errorf("%w: sent %d of %d replies: %v", ErrNotRetryable, i, len(chunks), err)
+	}
+
+	return cs.saveContext(message.ConversationID, userContext)
+}
+
+// saveContext persists a conversation turn after its replies were sent. The replies cannot
+// be taken back, so a failure is reported as not retryable.
+func (cs *ChannelService) saveContext(conversationID string, userContext entities.UserContext) error {
 	userContext.UpdatedAt = time.Now()
-	if _, err := cs.UserContextService.UpdateUserContext(to, userContext); err != nil {
+	if _, err := cs.UserContextService.UpdateUserContext(conversationID, userContext); err != nil {
 		cs.Logger.Error(fmt.Sprintf("Failed to update user context: %s", err.Error()))
-		return
+		return fmt.Errorf("%w: %v", ErrNotRetryable, err)
 	}
+	return nil
+}
 
-	messagesSplit := strings.Split(result.Response, ".")
+// sendOptions sends the last chunk of an AI response together with the options it offers:
+// as reply buttons when they fit, otherwise as a list message. Channels without interactive
+// messages get the options as numbered lines of text.
+func (cs *ChannelService) sendOptions(replyChannel channel.IChannel, message dto.InboundMessage, body string, options []dto.QueryAIOption) error {
+	to := message.ReplyTo
+	if len(options) > dto.MAX_LIST_ROWS {
+		cs.Logger.Warn(fmt.Sprintf("AI offered %d options to %s, only the first %d are sent", len(options), to, dto.MAX_LIST_ROWS))
+		options = options[:dto.MAX_LIST_ROWS]
+	}
 
-	cs.Logger.Info(fmt.Sprintf("Sending AI response messages to WhatsApp number: %s", to))
-	for i, message := range messagesSplit {
-		if strings.TrimSpace(message) != "" {
-			if i > 0 {
-				time.Sleep(2 * time.Second)
-			}
-			if err := cs.WhatsAppProvider.SendTextMessage(to, message); err != nil {
-				cs.Logger.Error(fmt.Sprintf("Failed to send WhatsApp message to %s: %s", to, err.Error()))
-				return
-			}
+	capabilities := replyChannel.Capabilities()
+	fitsButtons := capabilities.Buttons && len(options) <= dto.MAX_REPLY_BUTTONS
+	for _, option := range options {
+		if utf8.RuneCountInString(option.Title) > dto.MAX_REPLY_BUTTON_TITLE || option.Description != "" {
+			fitsButtons = false
 		}
 	}
-}
 
-func (cs *ChannelService) processAudio(userAudioUrl string, userContext entities.UserContext, to string) error {
-	authToken, err := cs.WhatsAppProvider.GenerateOAuth2Token()
-	if err != nil {
-		cs.Logger.Error(fmt.Sprintf("Error to generate OAuth2 token %v", err))
-		return err
+	switch {
+	case fitsButtons:
+		buttons := dto.ButtonsMessage{Body: body}
+		for _, option := range options {
+			buttons.Buttons = append(buttons.Buttons, dto.ReplyButton{ID: option.ID, Title: option.Title})
+		}
+		return cs.send(replyChannel, message, dto.ChannelMessage{Type: dto.OUTBOUND_PAYLOAD_BUTTONS, Buttons: &buttons})
+	case capabilities.List:
+		section := dto.ListSection{}
+		for _, option := range options {
+			section.Rows = append(section.Rows, dto.ListRow{ID: option.ID, Title: option.Title, Description: option.Description})
+		}
+		list := dto.ListMessage{Body: body, ButtonText: cs.OptionsButtonText, Sections: []dto.ListSection{section}}
+		return cs.send(replyChannel, message, dto.ChannelMessage{Type: dto.OUTBOUND_PAYLOAD_LIST, List: &list})
+	default:
+		lines := []string{strings.TrimSpace(body)}
+		for i, option := range options {
+			lines = append(lines, fmt.Sprintf("%d. %s", i+1, option.Title))
+		}
+		return cs.send(replyChannel, message, dto.ChannelMessage{Type: dto.OUTBOUND_PAYLOAD_TEXT, Text: strings.Join(lines, "\n")})
 	}
+}
 
-	result, err := cs.QueryAIService.ExecuteAudioQueryAI(userAudioUrl, authToken.AccessToken, userContext.Context)
+func (cs *ChannelService) processAudio(replyChannel channel.IChannel, message dto.InboundMessage, userContext entities.UserContext) error {
+	result, err := cs.QueryAIService.ExecuteAudioQueryAI(message.MediaURL, userContext.Context)
 	if err != nil {
 		cs.Logger.Error(fmt.Sprintf("Failed to execute AI query: %v", err))
 		return err
@@ -136,7 +290,7 @@ func (cs *ChannelService) processAudio(userAudioUrl string, userContext entities
 	userContext.Transcript = append(userContext.Transcript, entities.Transcript{
 		Role:      "user",
 		Message:   result.QueryText,
-		Audio:     userAudioUrl,
+		MediaID:   message.StoredMediaID,
 		Timestamp: time.Now(),
 	})
 
@@ -154,17 +308,30 @@ func (cs *ChannelService) processAudio(userAudioUrl string, userContext entities
 		userContext.Context = result.Response
 	}
 
-	userContext.UpdatedAt = time.Now()
-	if _, err := cs.UserContextService.UpdateUserContext(to, userContext); err != nil {
-		cs.Logger.Error(fmt.Sprintf("Failed to update user context: %s", err.Error()))
-		return err
+	if !replyChannel.Capabilities().Audio {
+		cs.Logger.Info(fmt.Sprintf("Channel %s cannot send audio, replying to %s with text", replyChannel.Name(), message.ReplyTo))
+		if err := cs.send(replyChannel, message, dto.ChannelMessage{Type: dto.OUTBOUND_PAYLOAD_TEXT, Text: result.Response}); err != nil {
+			return err
+		}
+		return cs.saveContext(message.ConversationID, userContext)
 	}
 
-	cs.Logger.Info(fmt.Sprintf("Sending AI response audio message to WhatsApp number: %s", to))
-	if err := cs.WhatsAppProvider.SendAudioMessage(to, result.AudioLink); err != nil {
-		cs.Logger.Error(fmt.Sprintf("Failed to send WhatsApp audio message to %s: %s", to, err.Error()))
+	audioLink := result.AudioLink
+	if cs.RehostAIAudio {
+		media, err := cs.MediaService.StoreRemote(result.AudioLink, message.ConversationID, dto.OUTBOUND_MEDIA_AUDIO)
+		if err != nil {
+			cs.Logger.Error(fmt.Sprintf("Failed to store AI audio response %s: %v", result.AudioLink, err))
+			return err
+		}
+		audioLink = cs.MediaService.URL(media)
+		userContext.Transcript[len(userContext.Transcript)-1].MediaID = media.ID.Hex()
+	}
+
+	cs.Logger.Info(fmt.Sprintf("Sending AI response audio message to %s through %s", message.ReplyTo, replyChannel.Name()))
+	audio := dto.OutboundMedia{Type: dto.OUTBOUND_MEDIA_AUDIO, Link: audioLink}
+	if err := cs.send(replyChannel, message, dto.ChannelMessage{Type: dto.OUTBOUND_PAYLOAD_AUDIO, Media: &audio}); err != nil {
 		return err
 	}
 
-	return nil
+	return cs.saveContext(message.ConversationID, userContext)
 }
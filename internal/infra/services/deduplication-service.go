@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+	"social-connector/internal/domain/interfaces/repository"
+	"social-connector/internal/infra/logger"
+	"sync/atomic"
+	"time"
+)
+
+// DeduplicationService drops inbound messages already accepted earlier, using the
+// provider message ID, so webhook redeliveries are acknowledged but not processed twice.
+type DeduplicationService struct {
+	Repository repository.ProcessedMessageRepository
+	Ctx        context.Context
+	Logger     *logger.Logger
+	TTL        time.Duration
+
+	duplicates atomic.Int64
+}
+
+func NewDeduplicationService(repository repository.ProcessedMessageRepository, ctx context.Context, logger *logger.Logger, ttl time.Duration) *DeduplicationService {
+	return &DeduplicationService{Repository: repository, Ctx: ctx, Logger: logger, TTL: ttl}
+}
+
+// Start creates the TTL index backing the dedup store.
+func (th *DeduplicationService) Start(ctx context.Context) error {
+	if err := th.Repository.EnsureIndexes(ctx, th.TTL); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to create processed messages indexes: %v", err))
+		return err
+	}
+	return nil
+}
+
+// Filter registers every message and returns only the ones seen for the first time.
+//
+// Messages without a provider ID cannot be deduplicated and are always returned.
+//
+// Returns:
+//   - []dto.InboundMessage: The messages that were not processed before, in their original order.
+//   - error: Returns an error if the dedup store could not be reached. Messages registered
+//     before the failure are released so a redelivery is not mistaken for a duplicate.
+func (th *DeduplicationService) Filter(messages []dto.InboundMessage) ([]dto.InboundMessage, error) {
+	fresh := []dto.InboundMessage{}
+
+	for _, message := range messages {
+		if message.ID == "" {
+			fresh = append(fresh, message)
+			continue
+		}
+
+		isNew, err := th.Repository.Register(th.Ctx, entities.ProcessedMessage{
+			ID:             dedupKey(message),
+			Provider:       message.Provider,
+			MessageID:      message.ID,
+			ConversationID: message.ConversationID,
+			CreatedAt:      time.Now(),
+		})
+		if err != nil {
+			th.Logger.Error(fmt.Sprintf("Failed to register inbound message %s: %v", message.ID, err))
+			th.Forget(fresh)
+			return nil, err
+		}
+
+		if !isNew {
+			total := th.duplicates.Add(1)
+			th.Logger.Warn(fmt.Sprintf("Duplicate inbound message %s from %s ignored, conversation ID %s, total duplicates %d", message.ID, message.Provider, message.ConversationID, total))
+			continue
+		}
+
+		fresh = append(fresh, message)
+	}
+
+	return fresh, nil
+}
+
+// Forget releases the dedup markers of messages that could not be accepted.
+func (th *DeduplicationService) Forget(messages []dto.InboundMessage) {
+	for _, message := range messages {
+		if message.ID == "" {
+			continue
+		}
+
+		if err := th.Repository.Remove(th.Ctx, dedupKey(message)); err != nil {
+			th.Logger.Error(fmt.Sprintf("Failed to release dedup marker of message %s: %v", message.ID, err))
+		}
+	}
+}
+
+// DuplicateCount returns how many duplicates were dropped since startup.
+func (th *DeduplicationService) DuplicateCount() int64 {
+	return th.duplicates.Load()
+}
+
+func dedupKey(message dto.InboundMessage) string {
+	return fmt.Sprintf("%s:%s", message.Provider, message.ID)
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+	"social-connector/internal/domain/interfaces/repository"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/logger"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	queuePollInterval = 1 * time.Second
+	queueMaxBackoff   = 5 * time.Minute
+)
+
+// InboundQueueService persists inbound messages before they are acknowledged and
+// consumes them with a bounded pool of workers holding leases on each item.
+//
+// Messages of the same conversation are processed one at a time and in arrival order,
+// even across instances, while different conversations are processed concurrently.
+type InboundQueueService struct {
+	Logger               *logger.Logger
+	Repository           repository.InboundQueueRepository
+	ChannelService       Iservices.IChannelServices
+	DeduplicationService Iservices.IDeduplicationService
+	Workers              int
+	MaxAttempts          int
+	LeaseDuration        time.Duration
+	Ctx                  context.Context
+
+	owner  string
+	wakeup chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewInboundQueueService(repository repository.InboundQueueRepository, channelService Iservices.IChannelServices, deduplicationService Iservices.IDeduplicationService, ctx context.Context, logger *logger.Logger, config config.QueueConfig) *InboundQueueService {
+	hostname, _ := os.Hostname()
+
+	return &InboundQueueService{
+		Logger:               logger,
+		Repository:           repository,
+		ChannelService:       channelService,
+		DeduplicationService: deduplicationService,
+		Workers:              config.Workers,
+		MaxAttempts:          config.MaxAttempts,
+		LeaseDuration:        time.Duration(config.LeaseSeconds) * time.Second,
+		Ctx:                  ctx,
+		owner:                fmt.Sprintf("%s-%s", hostname, primitive.NewObjectID().Hex()),
+		wakeup:               make(chan struct{}, config.Workers),
+	}
+}
+
+// Enqueue durably stores a batch of inbound messages, preserving their order.
+//
+// Messages already accepted before (webhook redeliveries) are dropped by the
+// deduplication service and only acknowledged.
+//
+// Parameters:
+//   - messages: []dto.InboundMessage - The normalized messages of a webhook batch.
+//
+// Returns:
+//   - error: Returns an error if the batch could not be persisted. Callers must not
+//     acknowledge the webhook in that case so the provider retries the delivery.
+func (th *InboundQueueService) Enqueue(messages []dto.InboundMessage) error {
+	messages, err := th.DeduplicationService.Filter(messages)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	items := make([]entities.InboundQueueItem, len(messages))
+	for i, message := range messages {
+		// Mongo keeps milliseconds, so offset creation time by whole milliseconds. The IDs are
+		// generated in order too and break ties with batches enqueued by another instance.
+		createdAt := now.Add(time.Duration(i) * time.Millisecond)
+		items[i] = entities.InboundQueueItem{
+			ID:             primitive.NewObjectID(),
+			ConversationID: message.ConversationID,
+			Message:        message,
+			Status:         entities.QUEUE_STATUS_PENDING,
+			AvailableAt:    createdAt,
+			CreatedAt:      createdAt,
+			UpdatedAt:      createdAt,
+		}
+	}
+
+	if err := th.Repository.Enqueue(th.Ctx, items); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to enqueue %d inbound messages: %v", len(messages), err))
+		th.DeduplicationService.Forget(messages)
+		return err
+	}
+
+	for range messages {
+		select {
+		case th.wakeup <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Start recovers work left behind by previous runs and launches the worker pool.
+// Workers stop when ctx is cancelled; use Wait to block until they have returned.
+func (th *InboundQueueService) Start(ctx context.Context) error {
+	if err := th.Repository.EnsureIndexes(ctx); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to create inbound queue indexes: %v", err))
+		return err
+	}
+
+	recovered, err := th.Repository.RequeueExpired(ctx)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to recover expired inbound queue leases: %v", err))
+		return err
+	}
+	if recovered > 0 {
+		th.Logger.Warn(fmt.Sprintf("Recovered %d inbound messages with expired leases", recovered))
+	}
+
+	th.Logger.Info(fmt.Sprintf("Starting %d inbound queue workers as %s", th.Workers, th.owner))
+	for i := 0; i < th.Workers; i++ {
+		th.wg.Add(1)
+		go th.worker(ctx, i)
+	}
+
+	return nil
+}
+
+// Wait blocks until every worker has returned.
+func (th *InboundQueueService) Wait() {
+	th.wg.Wait()
+}
+
+func (th *InboundQueueService) worker(ctx context.Context, id int) {
+	defer th.wg.Done()
+
+	owner := fmt.Sprintf("%s-%d", th.owner, id)
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		item, err := th.Repository.ClaimNext(ctx, owner, th.LeaseDuration)
+		if err != nil {
+			th.Logger.Error(fmt.Sprintf("Worker %d failed to claim inbound message: %v", id, err))
+		}
+
+		if item == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-th.wakeup:
+			case <-time.After(queuePollInterval):
+			}
+			continue
+		}
+
+		th.handle(ctx, owner, item)
+	}
+}
+
+func (th *InboundQueueService) handle(ctx context.Context, owner string, item *entities.InboundQueueItem) {
+	stopHeartbeat := th.heartbeat(ctx, owner, *item)
+	err := th.process(item.Message)
+	stopHeartbeat()
+
+	// Acknowledge with a fresh context so a shutdown does not leave finished work leased.
+	ackCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err == nil {
+		if err := th.Repository.Complete(ackCtx, *item, owner); err != nil {
+			th.Logger.Error(fmt.Sprintf("Failed to complete inbound message %s: %v", item.Message.ID, err))
+		}
+		return
+	}
+
+	th.Logger.Error(fmt.Sprintf("Failed to process inbound message %s conversation %s attempt %d/%d: %v", item.Message.ID, item.ConversationID, item.Attempts, th.MaxAttempts, err))
+
+	if item.Attempts >= th.MaxAttempts || errors.Is(err, ErrNotRetryable) {
+		if err := th.Repository.DeadLetter(ackCtx, *item, owner, err.Error()); err != nil {
+			th.Logger.Error(fmt.Sprintf("Failed to dead-letter inbound message %s: %v", item.Message.ID, err))
+			return
+		}
+		th.Logger.Warn(fmt.Sprintf("Inbound message %s moved to dead-letter after %d attempts", item.Message.ID, item.Attempts))
+		return
+	}
+
+	backoff := time.Duration(1<<uint(item.Attempts)) * time.Second
+	if backoff > queueMaxBackoff {
+		backoff = queueMaxBackoff
+	}
+
+	if err := th.Repository.Retry(ackCtx, *item, owner, time.Now().Add(backoff), err.Error()); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to reschedule inbound message %s: %v", item.Message.ID, err))
+	}
+}
+
+func (th *InboundQueueService) process(message dto.InboundMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered from panic: %v", r)
+		}
+	}()
+
+	return th.ChannelService.ProcessInbound(message)
+}
+
+// heartbeat keeps the lease of an item and of its conversation alive while it is being processed.
+func (th *InboundQueueService) heartbeat(ctx context.Context, owner string, item entities.InboundQueueItem) func() {
+	done := make(chan struct{})
+	ticker := time.NewTicker(th.LeaseDuration / 2)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := th.Repository.ExtendLease(ctx, item, owner, th.LeaseDuration); err != nil {
+					th.Logger.Warn(fmt.Sprintf("Failed to extend lease of inbound item %s: %v", item.ID.Hex(), err))
+				}
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
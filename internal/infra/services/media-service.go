@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+	"social-connector/internal/domain/interfaces/repository"
+	"social-connector/internal/infra/channel"
+	"social-connector/internal/infra/logger"
+	"social-connector/internal/infra/storage"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MEDIA_SOURCE_REMOTE marks media downloaded from a plain URL instead of a provider.
+const MEDIA_SOURCE_REMOTE = "remote"
+
+// MediaService downloads inbound media from the providers, keeps it in the blob store and
+// exposes it through connector URLs, so provider credentials never leave the connector.
+type MediaService struct {
+	Repository    repository.MediaRepository
+	BlobStore     storage.IBlobStore
+	Channels      *channel.Registry
+	Ctx           context.Context
+	Logger        *logger.Logger
+	HttpClient    *http.Client
+	MaxSize       int64
+	PublicBaseURL string
+	SigningSecret []byte
+	URLTTL        time.Duration
+}
+
+func NewMediaService(repository repository.MediaRepository, blobStore storage.IBlobStore, channels *channel.Registry, httpClient *http.Client, ctx context.Context, logger *logger.Logger, config config.MediaConfig) *MediaService {
+	return &MediaService{
+		Repository:    repository,
+		BlobStore:     blobStore,
+		Channels:      channels,
+		Ctx:           ctx,
+		Logger:        logger,
+		HttpClient:    httpClient,
+		MaxSize:       int64(config.MaxSizeMB) * 1024 * 1024,
+		PublicBaseURL: strings.TrimRight(config.PublicBaseURL, "/"),
+		SigningSecret: []byte(config.SigningSecret),
+		URLTTL:        time.Duration(config.URLTTLMinutes) * time.Minute,
+	}
+}
+
+// Start creates the indexes backing the media collection.
+func (th *MediaService) Start(ctx context.Context) error {
+	if err := th.Repository.EnsureIndexes(ctx); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to create media indexes: %v", err))
+		return err
+	}
+	return nil
+}
+
+// StoreInbound downloads the media of an inbound message and stores it in the blob store.
+//
+// Media already stored for the same provider media (e.g. when the message is retried) is
+// returned without downloading it again.
+//
+// Parameters:
+//   - message: dto.InboundMessage - A normalized inbound message with Media set.
+//
+// Returns:
+//   - entities.StoredMedia: The media record with its checksum, MIME type and size.
+//   - error: Returns an error if the message has no media, the download fails, the media is
+//     larger than media.max_size_mb or it cannot be stored.
+func (th *MediaService) StoreInbound(message dto.InboundMessage) (entities.StoredMedia, error) {
+	if message.Media == nil {
+		return entities.StoredMedia{}, fmt.Errorf("message %s has no media", message.ID)
+	}
+
+	inboundChannel, err := th.Channels.ResolveMessage(message)
+	if err != nil {
+		return entities.StoredMedia{}, err
+	}
+
+	providerMediaID := message.Media.ID
+	if providerMediaID == "" {
+		providerMediaID = message.Media.URL
+	}
+
+	existing, err := th.Repository.FindByProviderMediaID(th.Ctx, message.Provider, providerMediaID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return entities.StoredMedia{}, err
+	}
+
+	downloaded, err := inboundChannel.DownloadMedia(*message.Media)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to download media of message %s: %v", message.ID, err))
+		return entities.StoredMedia{}, err
+	}
+	defer downloaded.Body.Close()
+
+	return th.store(downloaded, entities.StoredMedia{
+		Provider:        message.Provider,
+		ProviderMediaID: providerMediaID,
+		MessageID:       message.ID,
+		ConversationID:  message.ConversationID,
+		Type:            message.Type,
+	})
+}
+
+// StoreRemote downloads a media from a plain URL, such as the audio answer of the AI service,
+// and stores it so it can be sent to the providers through a signed connector URL.
+func (th *MediaService) StoreRemote(link string, conversationID string, mediaType string) (entities.StoredMedia, error) {
+	existing, err := th.Repository.FindByProviderMediaID(th.Ctx, MEDIA_SOURCE_REMOTE, link)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return entities.StoredMedia{}, err
+	}
+
+	req, err := http.NewRequestWithContext(th.Ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return entities.StoredMedia{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	res, err := th.HttpClient.Do(req)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to download %s: %v", link, err))
+		return entities.StoredMedia{}, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return entities.StoredMedia{}, fmt.Errorf("unexpected HTTP status %d downloading %s", res.StatusCode, link)
+	}
+	defer res.Body.Close()
+
+	return th.store(dto.DownloadedMedia{Body: res.Body, MimeType: res.Header.Get("Content-Type")}, entities.StoredMedia{
+		Provider:        MEDIA_SOURCE_REMOTE,
+		ProviderMediaID: link,
+		ConversationID:  conversationID,
+		Type:            mediaType,
+	})
+}
+
+// store reads a downloaded media up to the size limit, computes its checksum and MIME type
+// and saves it in the blob store and the media collection.
+func (th *MediaService) store(downloaded dto.DownloadedMedia, media entities.StoredMedia) (entities.StoredMedia, error) {
+	data, err := io.ReadAll(io.LimitReader(downloaded.Body, th.MaxSize+1))
+	if err != nil {
+		return entities.StoredMedia{}, fmt.Errorf("failed to read media %s: %w", media.ProviderMediaID, err)
+	}
+	if int64(len(data)) > th.MaxSize {
+		return entities.StoredMedia{}, fmt.Errorf("media %s is larger than %d bytes", media.ProviderMediaID, th.MaxSize)
+	}
+
+	mimeType := downloaded.MimeType
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		mimeType = mediaType
+	} else {
+		mimeType = http.DetectContentType(data)
+	}
+
+	sum := sha256.Sum256(data)
+	media.SHA256 = hex.EncodeToString(sum[:])
+	media.Key = fmt.Sprintf("%s/%s/%s%s", media.Provider, media.ConversationID, media.SHA256, extensionFor(mimeType))
+	media.MimeType = mimeType
+	media.Filename = downloaded.Filename
+	media.Size = int64(len(data))
+	media.CreatedAt = time.Now()
+
+	if err := th.BlobStore.Put(th.Ctx, media.Key, data, mimeType); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to store media %s: %v", media.ProviderMediaID, err))
+		return entities.StoredMedia{}, err
+	}
+
+	stored, err := th.Repository.Create(th.Ctx, media)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to record media %s: %v", media.ProviderMediaID, err))
+		return entities.StoredMedia{}, err
+	}
+
+	th.Logger.Info(fmt.Sprintf("Stored %s media, %d bytes, %s", mimeType, stored.Size, stored.Key))
+	return stored, nil
+}
+
+func (th *MediaService) FindMedia(id string) (entities.StoredMedia, error) {
+	return th.Repository.FindByID(th.Ctx, id)
+}
+
+// Open returns the content of a stored media as a seekable stream; the caller closes it.
+func (th *MediaService) Open(media entities.StoredMedia) (io.ReadSeekCloser, error) {
+	return storage.NewBlobReader(th.Ctx, th.BlobStore, media.Key, media.Size), nil
+}
+
+// URL returns an expiring connector URL serving a stored media, signed with media.signing_secret.
+// Anyone holding the URL can fetch the media until it expires, without other credentials.
+func (th *MediaService) URL(media entities.StoredMedia) string {
+	id := media.ID.Hex()
+	expires := strconv.FormatInt(time.Now().Add(th.URLTTL).Unix(), 10)
+	return fmt.Sprintf("%s/media/%s?expires=%s&signature=%s", th.PublicBaseURL, id, expires, th.sign(id, expires))
+}
+
+// VerifySignature reports whether a media URL was signed by URL and has not expired yet.
+func (th *MediaService) VerifySignature(id string, expires string, signature string) bool {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+
+	expected, err := hex.DecodeString(th.sign(id, expires))
+	if err != nil {
+		return false
+	}
+	provided, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, provided)
+}
+
+func (th *MediaService) sign(id string, expires string) string {
+	mac := hmac.New(sha256.New, th.SigningSecret)
+	mac.Write([]byte(id + ":" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func extensionFor(mimeType string) string {
+	extensions, err := mime.ExtensionsByType(mimeType)
+	if err != nil || len(extensions) == 0 {
+		return ""
+	}
+	return extensions[0]
+}
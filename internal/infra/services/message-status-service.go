@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+	"social-connector/internal/domain/interfaces/repository"
+	"social-connector/internal/infra/events"
+	"social-connector/internal/infra/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MessageStatusService stores delivery status notifications against the outbound
+// message record and publishes them on the event bus.
+type MessageStatusService struct {
+	Repository repository.OutboundMessageRepository
+	EventBus   *events.Bus
+	Ctx        context.Context
+	Logger     *logger.Logger
+}
+
+func NewMessageStatusService(repository repository.OutboundMessageRepository, eventBus *events.Bus, ctx context.Context, logger *logger.Logger) *MessageStatusService {
+	return &MessageStatusService{Repository: repository, EventBus: eventBus, Ctx: ctx, Logger: logger}
+}
+
+// Start creates the indexes backing the outbound message collection.
+func (th *MessageStatusService) Start(ctx context.Context) error {
+	if err := th.Repository.EnsureIndexes(ctx); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to create outbound messages indexes: %v", err))
+		return err
+	}
+	return nil
+}
+
+// RecordSent persists an outbound message accepted by a provider in the outbound message log.
+//
+// Parameters:
+//   - conversationID: string - The conversation the message belongs to.
+//   - recipient: string - The phone number the message was sent to.
+//   - payloadType: string - The kind of message sent (dto.OUTBOUND_PAYLOAD_*).
+//   - content: string - The text, media link or template name that was sent.
+//   - result: dto.SendResult - The provider and message ID returned by the provider.
+//
+// When the provider returned no message ID the record is still stored, under a generated
+// ID flagged with ProviderIDMissing; status reports cannot be matched to it.
+//
+// Returns:
+//   - entities.OutboundMessage: The stored record.
+//   - error: Returns an error if the record could not be stored.
+func (th *MessageStatusService) RecordSent(conversationID string, recipient string, payloadType string, content string, result dto.SendResult) (entities.OutboundMessage, error) {
+	providerMessageID := result.ProviderMessageID
+	providerIDMissing := providerMessageID == ""
+	if providerIDMissing {
+		providerMessageID = "local-" + primitive.NewObjectID().Hex()
+		th.Logger.Warn(fmt.Sprintf("%s returned no message ID for %s, storing it as %s", result.Provider, recipient, providerMessageID))
+	}
+
+	message, err := th.Repository.SaveSent(th.Ctx, entities.OutboundMessage{
+		Provider:          result.Provider,
+		ProviderMessageID: providerMessageID,
+		ProviderIDMissing: providerIDMissing,
+		ConversationID:    conversationID,
+		Recipient:         recipient,
+		PayloadType:       payloadType,
+		Content:           content,
+		Status:            result.Status,
+	})
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to store outbound message %s: %v", providerMessageID, err))
+		return entities.OutboundMessage{}, err
+	}
+
+	return message, nil
+}
+
+// RecordStatus appends a status event to the history of its outbound message.
+//
+// The current status of the record only moves forward (sent, delivered, read), except
+// for failures which always replace it. Every event is published as
+// events.MESSAGE_STATUS_UPDATED and failures additionally as events.MESSAGE_FAILED.
+func (th *MessageStatusService) RecordStatus(event dto.MessageStatusEvent) (entities.OutboundMessage, error) {
+	advance := true
+	current, err := th.Repository.FindByProviderMessageID(th.Ctx, event.Provider, event.ProviderMessageID)
+	if err == nil {
+		advance = dto.StatusRank(event.Status) >= dto.StatusRank(current.Status)
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		th.Logger.Error(fmt.Sprintf("Failed to load outbound message %s: %v", event.ProviderMessageID, err))
+		return entities.OutboundMessage{}, err
+	}
+
+	message, err := th.Repository.AppendStatus(th.Ctx, event, advance)
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to record status %s for message %s: %v", event.Status, event.ProviderMessageID, err))
+		return entities.OutboundMessage{}, err
+	}
+
+	th.Logger.Info(fmt.Sprintf("Message %s from %s is %s, conversation ID %s", event.ProviderMessageID, event.Provider, event.Status, message.ConversationID))
+
+	th.EventBus.Publish(events.MESSAGE_STATUS_UPDATED, event)
+	if event.Status == dto.MESSAGE_STATUS_FAILED {
+		th.EventBus.Publish(events.MESSAGE_FAILED, message)
+	}
+
+	return message, nil
+}
+
+// FindMessage returns the outbound message record with its status history.
+func (th *MessageStatusService) FindMessage(provider string, providerMessageID string) (entities.OutboundMessage, error) {
+	return th.Repository.FindByProviderMessageID(th.Ctx, provider, providerMessageID)
+}
+
+// FindConversationMessages returns the outbound messages of a conversation, newest first.
+func (th *MessageStatusService) FindConversationMessages(conversationID string) ([]entities.OutboundMessage, error) {
+	return th.Repository.FindByConversationID(th.Ctx, conversationID)
+}
@@ -13,11 +13,13 @@ import (
 
 type QueryAIService struct {
 	Logger *logger.Logger
+	Host   string
 }
 
-func NewQueryAIService(logger *logger.Logger) *QueryAIService {
+func NewQueryAIService(logger *logger.Logger, config config.QueryAIConfig) *QueryAIService {
 	return &QueryAIService{
 		Logger: logger,
+		Host:   config.Host,
 	}
 }
 
@@ -36,9 +38,9 @@ func NewQueryAIService(logger *logger.Logger) *QueryAIService {
 // This function depends on an AI service integration, such as OpenAI, Google Cloud AI,
 // or another machine learning model API.
 func (th *QueryAIService) ExecuteQueryAI(queryText string, context string) (dto.QueryAIResponse, error) {
-	queryAIHost := config.GetEnv("QUERY_AI_API_HOST")
+	queryAIHost := th.Host
 	if queryAIHost == "" {
-		err := "QUERY_AI_API_HOST environment variable not set."
+		err := "QUERY_AI_API_HOST is not set."
 		th.Logger.Error(err)
 		return dto.QueryAIResponse{}, fmt.Errorf("%s", err)
 	}
@@ -78,7 +80,7 @@ func (th *QueryAIService) ExecuteQueryAI(queryText string, context string) (dto.
 // ExecuteAudioQueryAI processes a audio using an AI service and returns the response.
 //
 // Parameters:
-// - audioUrl (string): The audio query to be processed by the AI service.
+// - audioUrl (string): The connector URL of the stored audio to be processed by the AI service.
 //
 // Returns:
 //   - dto.QueryAIResponse: A structured response object containing the AI's output,
@@ -89,17 +91,16 @@ func (th *QueryAIService) ExecuteQueryAI(queryText string, context string) (dto.
 // Note:
 // This function depends on an AI service integration, such as OpenAI, Google Cloud AI,
 // or another machine learning model API.
-func (th *QueryAIService) ExecuteAudioQueryAI(audioUrl string, audioAuth string, context string) (dto.VoiceQueryAIResponse, error) {
-	queryAIHost := config.GetEnv("QUERY_AI_API_HOST")
+func (th *QueryAIService) ExecuteAudioQueryAI(audioUrl string, context string) (dto.VoiceQueryAIResponse, error) {
+	queryAIHost := th.Host
 	if queryAIHost == "" {
-		err := "QUERY_AI_API_HOST environment variable not set."
+		err := "QUERY_AI_API_HOST is not set."
 		th.Logger.Error(err)
 		return dto.VoiceQueryAIResponse{}, fmt.Errorf("%s", err)
 	}
 
 	payload := map[string]string{
 		"audio_url":       audioUrl,
-		"audio_auth":      audioAuth,
 		"message_context": context,
 	}
 	payloadBytes, err := json.Marshal(payload)
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/logger"
+	"social-connector/internal/infra/provider"
+	"sync"
+	"time"
+)
+
+const telegramPollRetryDelay = 5 * time.Second
+
+// TelegramPollingService fetches Telegram updates with getUpdates instead of receiving them
+// on the webhook, for deployments the Bot API cannot reach. Updates go through the same
+// inbound queue as webhook deliveries.
+type TelegramPollingService struct {
+	Logger              *logger.Logger
+	Provider            *provider.TelegramProvider
+	InboundQueueService Iservices.IInboundQueueService
+	Timeout             int
+
+	offset int64
+	wg     sync.WaitGroup
+}
+
+func NewTelegramPollingService(logger *logger.Logger, telegramProvider *provider.TelegramProvider, inboundQueueService Iservices.IInboundQueueService, config config.TelegramConfig) *TelegramPollingService {
+	return &TelegramPollingService{
+		Logger:              logger,
+		Provider:            telegramProvider,
+		InboundQueueService: inboundQueueService,
+		Timeout:             config.PollTimeoutSeconds,
+	}
+}
+
+// Start removes the bot's webhook, which Telegram requires for getUpdates, and launches the
+// polling loop. The loop stops when ctx is cancelled; use Wait to block until it has returned.
+func (th *TelegramPollingService) Start(ctx context.Context) error {
+	if err := th.Provider.DeleteWebhook(ctx); err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to remove Telegram webhook: %v", err))
+		return err
+	}
+
+	th.Logger.Info("Starting Telegram long polling")
+	th.wg.Add(1)
+	go th.poll(ctx)
+	return nil
+}
+
+// Wait blocks until the polling loop has returned.
+func (th *TelegramPollingService) Wait() {
+	th.wg.Wait()
+}
+
+// poll confirms updates by advancing the offset only after they are queued, so updates
+// that failed to queue are fetched again. Redeliveries are dropped by the deduplication store.
+func (th *TelegramPollingService) poll(ctx context.Context) {
+	defer th.wg.Done()
+
+	for ctx.Err() == nil {
+		updates, err := th.Provider.GetUpdates(ctx, th.offset, th.Timeout)
+		if err == nil {
+			err = th.enqueue(updates)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			th.Logger.Error(fmt.Sprintf("Failed to poll Telegram updates: %v", err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(telegramPollRetryDelay):
+			}
+		}
+	}
+}
+
+func (th *TelegramPollingService) enqueue(updates []dto.TelegramUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	messages := []dto.InboundMessage{}
+	for _, update := range updates {
+		if message, ok := update.ToInboundMessage(); ok {
+			messages = append(messages, message)
+		}
+	}
+
+	if len(messages) > 0 {
+		th.Logger.Info(fmt.Sprintf("Received %d Telegram messages.", len(messages)))
+		if err := th.InboundQueueService.Enqueue(messages); err != nil {
+			return err
+		}
+	}
+
+	th.offset = updates[len(updates)-1].UpdateID + 1
+	return nil
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/channel"
+	"social-connector/internal/infra/logger"
+	"social-connector/internal/util/phone"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*(\d+)\s*\}\}`)
+
+// TemplateService sends WhatsApp templates (HSM) through the channels after checking the
+// placeholders against the local template registry.
+type TemplateService struct {
+	Logger               *logger.Logger
+	Channels             *channel.Registry
+	MessageStatusService Iservices.IMessageStatusService
+	Templates            map[string]entities.MessageTemplate
+}
+
+// NewTemplateService loads the template registry from the YAML file configured in
+// templates.file. Without a file the registry is empty and every send is rejected.
+func NewTemplateService(logger *logger.Logger, channels *channel.Registry, messageStatusService Iservices.IMessageStatusService, config config.TemplatesConfig) (*TemplateService, error) {
+	service := &TemplateService{Logger: logger, Channels: channels, MessageStatusService: messageStatusService, Templates: map[string]entities.MessageTemplate{}}
+
+	if config.File == "" {
+		logger.Warn("No template registry configured, template messages are disabled")
+		return service, nil
+	}
+
+	content, err := os.ReadFile(config.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template registry %s: %w", config.File, err)
+	}
+
+	var registry struct {
+		Templates []entities.MessageTemplate `yaml:"templates"`
+	}
+	if err := yaml.Unmarshal(content, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse template registry %s: %w", config.File, err)
+	}
+
+	for _, template := range registry.Templates {
+		if template.Name == "" || len(template.Languages) == 0 {
+			return nil, fmt.Errorf("template registry %s has a template without name or languages", config.File)
+		}
+		service.Templates[template.Name] = template
+	}
+
+	logger.Info(fmt.Sprintf("Loaded %d message templates from %s", len(service.Templates), config.File))
+	return service, nil
+}
+
+// ListTemplates returns the registered templates sorted by name.
+func (th *TemplateService) ListTemplates() []entities.MessageTemplate {
+	templates := make([]entities.MessageTemplate, 0, len(th.Templates))
+	for _, template := range th.Templates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+func (th *TemplateService) FindTemplate(name string) (entities.MessageTemplate, bool) {
+	template, ok := th.Templates[name]
+	return template, ok
+}
+
+// SendTemplate validates a template request against the registry, sends it through the
+// requested provider and stores it in the outbound message log.
+//
+// When the requested language has no variant the default language of the template is used.
+//
+// Parameters:
+//   - request: dto.SendTemplateRequest - The provider, recipient, template and placeholder values.
+//
+// A template the provider accepted is reported as sent even when the outbound record cannot be
+// stored, so callers do not retry and deliver it twice.
+//
+// Returns:
+//   - entities.OutboundMessage: The stored outbound message.
+//   - error: Returns Iservices.ErrInvalidTemplateRequest if the provider or template is unknown, the recipient is not a valid
+//     phone number or the placeholders do not match the template, and Iservices.ErrTemplateNotSent if the provider rejects
+//     the message or cannot be reached.
+func (th *TemplateService) SendTemplate(request dto.SendTemplateRequest) (entities.OutboundMessage, error) {
+	templateChannel, err := th.Channels.Resolve(request.Provider)
+	if err != nil {
+		return entities.OutboundMessage{}, fmt.Errorf("%w: %v", Iservices.ErrInvalidTemplateRequest, err)
+	}
+	if !templateChannel.Capabilities().Templates {
+		return entities.OutboundMessage{}, fmt.Errorf("%w: channel %s does not support template messages", Iservices.ErrInvalidTemplateRequest, templateChannel.Name())
+	}
+
+	number, err := phone.Parse(request.To)
+	if err != nil {
+		return entities.OutboundMessage{}, fmt.Errorf("%w: %v", Iservices.ErrInvalidTemplateRequest, err)
+	}
+	to := dto.FormatRecipient(request.Provider, number.Digits())
+
+	template, ok := th.Templates[request.Template]
+	if !ok {
+		return entities.OutboundMessage{}, fmt.Errorf("%w: template %s is not registered", Iservices.ErrInvalidTemplateRequest, request.Template)
+	}
+
+	language := request.Language
+	variant, ok := template.Languages[language]
+	if !ok {
+		language = template.DefaultLanguage
+		variant, ok = template.Languages[language]
+		if !ok {
+			return entities.OutboundMessage{}, fmt.Errorf("%w: template %s has no %s variant", Iservices.ErrInvalidTemplateRequest, template.Name, request.Language)
+		}
+	}
+
+	message := dto.TemplateMessage{
+		Name:     template.Name,
+		Language: language,
+		Header:   request.Header,
+		Body:     request.Body,
+		Buttons:  request.Buttons,
+	}
+	if err := validateTemplate(variant, message); err != nil {
+		return entities.OutboundMessage{}, fmt.Errorf("%w: template %s (%s): %v", Iservices.ErrInvalidTemplateRequest, template.Name, language, err)
+	}
+
+	result, err := templateChannel.Send(to, dto.ChannelMessage{Type: dto.OUTBOUND_PAYLOAD_TEMPLATE, Template: &message})
+	if err != nil {
+		th.Logger.Error(fmt.Sprintf("Failed to send template %s to %s: %v", template.Name, to, err))
+		return entities.OutboundMessage{}, fmt.Errorf("%w: %v", Iservices.ErrTemplateNotSent, err)
+	}
+
+	conversationID := request.ConversationID
+	if conversationID == "" {
+		conversationID = dto.PhoneConversationID(number.Digits())
+	}
+
+	sent, err := th.MessageStatusService.RecordSent(conversationID, to, dto.OUTBOUND_PAYLOAD_TEMPLATE, template.Name, result)
+	if err != nil {
+		// The template already left: report it as sent with the details known so far.
+		th.Logger.Error(fmt.Sprintf("Failed to record template %s sent to %s: %v", template.Name, to, err))
+		return entities.OutboundMessage{
+			Provider:          result.Provider,
+			ProviderMessageID: result.ProviderMessageID,
+			ConversationID:    conversationID,
+			Recipient:         to,
+			PayloadType:       dto.OUTBOUND_PAYLOAD_TEMPLATE,
+			Content:           template.Name,
+			Status:            result.Status,
+		}, nil
+	}
+	return sent, nil
+}
+
+// validateTemplate checks that the header, body and button values match the placeholders of the variant.
+func validateTemplate(variant entities.TemplateVariant, message dto.TemplateMessage) error {
+	if expected := countPlaceholders(variant.Body); len(message.Body) != expected {
+		return fmt.Errorf("body expects %d parameters, got %d", expected, len(message.Body))
+	}
+
+	header := variant.Header
+	switch {
+	case header == nil && message.Header != nil:
+		return fmt.Errorf("template has no header")
+	case header == nil:
+	case strings.ToLower(header.Format) == dto.TEMPLATE_HEADER_TEXT:
+		if countPlaceholders(header.Text) == 0 {
+			if message.Header != nil {
+				return fmt.Errorf("header has no placeholder")
+			}
+			break
+		}
+		if message.Header == nil || message.Header.Type != dto.TEMPLATE_HEADER_TEXT || message.Header.Text == "" {
+			return fmt.Errorf("header expects a text parameter")
+		}
+	default:
+		if message.Header == nil || message.Header.Type != strings.ToLower(header.Format) || message.Header.MediaURL == "" {
+			return fmt.Errorf("header expects a %s link", strings.ToLower(header.Format))
+		}
+	}
+
+	provided := map[int]bool{}
+	for _, button := range message.Buttons {
+		if button.Index < 0 || button.Index >= len(variant.Buttons) {
+			return fmt.Errorf("button %d does not exist", button.Index)
+		}
+		if strings.ToLower(variant.Buttons[button.Index].Type) != button.Type {
+			return fmt.Errorf("button %d is a %s button", button.Index, strings.ToLower(variant.Buttons[button.Index].Type))
+		}
+		if button.Parameter == "" {
+			return fmt.Errorf("button %d has an empty parameter", button.Index)
+		}
+		provided[button.Index] = true
+	}
+
+	for index, button := range variant.Buttons {
+		if strings.ToLower(button.Type) == dto.TEMPLATE_BUTTON_URL && countPlaceholders(button.URL) > 0 && !provided[index] {
+			return fmt.Errorf("button %d expects a URL parameter", index)
+		}
+	}
+
+	return nil
+}
+
+// countPlaceholders returns the highest {{n}} placeholder of a template text.
+func countPlaceholders(text string) int {
+	highest := 0
+	for _, match := range templatePlaceholder.FindAllStringSubmatch(text, -1) {
+		if index, err := strconv.Atoi(match[1]); err == nil && index > highest {
+			highest = index
+		}
+	}
+	return highest
+}
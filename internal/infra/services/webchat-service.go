@@ -0,0 +1,165 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"social-connector/internal/config"
+	"social-connector/internal/domain/dto"
+	"social-connector/internal/domain/entities"
+	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/logger"
+	"social-connector/internal/infra/webchat"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WebChatService handles the anonymous visitor sessions of the website widget. Visitor
+// messages go through the inbound queue like any provider webhook, so the replies come
+// from the same pipeline and the transcript is stored in the UserContext collection.
+type WebChatService struct {
+	Logger              *logger.Logger
+	InboundQueueService Iservices.IInboundQueueService
+	UserContextService  Iservices.IUserContextService
+	MediaService        Iservices.IMediaService
+	Hub                 *webchat.Hub
+	SigningSecret       []byte
+	SessionTTL          time.Duration
+	MaxMessageLength    int
+}
+
+func NewWebChatService(logger *logger.Logger, inboundQueueService Iservices.IInboundQueueService, userContextService Iservices.IUserContextService, mediaService Iservices.IMediaService, hub *webchat.Hub, config config.WebChatConfig) *WebChatService {
+	return &WebChatService{
+		Logger:              logger,
+		InboundQueueService: inboundQueueService,
+		UserContextService:  userContextService,
+		MediaService:        mediaService,
+		Hub:                 hub,
+		SigningSecret:       []byte(config.SigningSecret),
+		SessionTTL:          time.Duration(config.SessionTTLHours) * time.Hour,
+		MaxMessageLength:    config.MaxMessageLength,
+	}
+}
+
+// CreateSession starts an anonymous visitor session. The token has the form
+// sessionID.expires.signature, so it is verified without a database lookup.
+func (th *WebChatService) CreateSession() (dto.WebChatSession, error) {
+	sessionID := primitive.NewObjectID().Hex()
+	expiresAt := time.Now().Add(th.SessionTTL).UTC().Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	th.Logger.Info(fmt.Sprintf("Web chat session %s created", sessionID))
+	return dto.WebChatSession{
+		SessionID: sessionID,
+		Token:     fmt.Sprintf("%s.%s.%s", sessionID, expires, th.sign(sessionID, expires)),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// VerifyToken returns the session ID of a token issued by CreateSession that has not expired yet.
+func (th *WebChatService) VerifyToken(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed session token")
+	}
+	sessionID, expires, signature := parts[0], parts[1], parts[2]
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return "", fmt.Errorf("session token expired")
+	}
+
+	expected, _ := hex.DecodeString(th.sign(sessionID, expires))
+	provided, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, provided) {
+		return "", fmt.Errorf("invalid session token")
+	}
+
+	return sessionID, nil
+}
+
+// ReceiveMessage queues a visitor message for the conversation pipeline.
+//
+// Parameters:
+//   - sessionID: string - The session the message was sent in, taken from a verified token.
+//   - message: dto.WebChatInbound - The text typed by the visitor, or the option it picked.
+//
+// Returns:
+//   - error: Returns an error if the text is empty or too long, or the message cannot be queued.
+func (th *WebChatService) ReceiveMessage(sessionID string, message dto.WebChatInbound) error {
+	text := strings.TrimSpace(message.Text)
+	if text == "" {
+		return fmt.Errorf("message cannot be empty")
+	}
+	if utf8.RuneCountInString(text) > th.MaxMessageLength {
+		return fmt.Errorf("message cannot be longer than %d characters", th.MaxMessageLength)
+	}
+
+	id := message.ID
+	if id == "" {
+		id = primitive.NewObjectID().Hex()
+	}
+
+	inbound := dto.InboundMessage{
+		// IDs chosen by the widget are only unique within the session.
+		ID:             sessionID + ":" + id,
+		Provider:       dto.PROVIDER_WEBCHAT,
+		ConversationID: dto.WebChatConversationID(sessionID),
+		From:           sessionID,
+		ReplyTo:        sessionID,
+		Type:           dto.INBOUND_MESSAGE_TEXT,
+		Text:           text,
+		ReceivedAt:     time.Now(),
+	}
+	if message.ReplyID != "" {
+		inbound.Type = dto.INBOUND_MESSAGE_BUTTON_REPLY
+		inbound.Reply = &dto.InboundReply{ID: message.ReplyID, Title: text}
+	}
+
+	return th.InboundQueueService.Enqueue([]dto.InboundMessage{inbound})
+}
+
+// Transcript returns the conversation of a session so the widget can restore it after a reload.
+// A session without messages has an empty transcript. Audio kept in the media store gets a
+// freshly signed URL, since the transcript only holds the media ID.
+func (th *WebChatService) Transcript(sessionID string) ([]entities.Transcript, error) {
+	userContext, err := th.UserContextService.FindContext(dto.WebChatConversationID(sessionID))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return []entities.Transcript{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, turn := range userContext.Transcript {
+		if turn.MediaID == "" {
+			continue
+		}
+		media, err := th.MediaService.FindMedia(turn.MediaID)
+		if err != nil {
+			th.Logger.Warn(fmt.Sprintf("Failed to load media %s of web chat session %s: %v", turn.MediaID, sessionID, err))
+			continue
+		}
+		userContext.Transcript[i].Audio = th.MediaService.URL(media)
+	}
+
+	return userContext.Transcript, nil
+}
+
+// Subscribe opens the stream of agent replies of a session.
+func (th *WebChatService) Subscribe(sessionID string) (<-chan dto.WebChatEvent, func()) {
+	return th.Hub.Subscribe(sessionID)
+}
+
+func (th *WebChatService) sign(sessionID string, expires string) string {
+	mac := hmac.New(sha256.New, th.SigningSecret)
+	mac.Write([]byte(sessionID + ":" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
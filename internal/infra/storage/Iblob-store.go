@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound is returned by every blob store when the key does not exist.
+var ErrBlobNotFound = errors.New("blob not found")
+
+type IBlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
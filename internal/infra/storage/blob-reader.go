@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// BlobReader reads a blob of known size as an io.ReadSeeker, so it can be served with
+// http.ServeContent. Every seek followed by a read opens a range request on the store,
+// which keeps range requests cheap for remote stores.
+type BlobReader struct {
+	ctx    context.Context
+	store  IBlobStore
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func NewBlobReader(ctx context.Context, store IBlobStore, key string, size int64) *BlobReader {
+	return &BlobReader{ctx: ctx, store: store, key: key, size: size}
+}
+
+func (th *BlobReader) Read(p []byte) (int, error) {
+	if th.offset >= th.size {
+		return 0, io.EOF
+	}
+
+	if th.body == nil {
+		body, err := th.store.GetRange(th.ctx, th.key, th.offset, th.size-th.offset)
+		if err != nil {
+			return 0, err
+		}
+		th.body = body
+	}
+
+	n, err := th.body.Read(p)
+	th.offset += int64(n)
+	return n, err
+}
+
+func (th *BlobReader) Seek(offset int64, whence int) (int64, error) {
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = th.offset + offset
+	case io.SeekEnd:
+		target = th.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if target < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if target != th.offset && th.body != nil {
+		th.body.Close()
+		th.body = nil
+	}
+	th.offset = target
+	return target, nil
+}
+
+func (th *BlobReader) Close() error {
+	if th.body == nil {
+		return nil
+	}
+	return th.body.Close()
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"social-connector/internal/config"
+)
+
+const (
+	BLOB_STORE_LOCAL = "local"
+	BLOB_STORE_S3    = "s3"
+)
+
+// NewBlobStore builds the blob store selected by media.store.
+func NewBlobStore(httpClient *http.Client, config config.MediaConfig) (IBlobStore, error) {
+	switch config.Store {
+	case BLOB_STORE_LOCAL:
+		return NewLocalBlobStore(config.LocalPath)
+	case BLOB_STORE_S3:
+		return NewS3BlobStore(httpClient, config.S3), nil
+	default:
+		return nil, fmt.Errorf("unknown media store %s", config.Store)
+	}
+}
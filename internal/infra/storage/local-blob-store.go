@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStore keeps blobs as files below Root, one file per key.
+type LocalBlobStore struct {
+	Root string
+}
+
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create media directory %s: %w", root, err)
+	}
+	return &LocalBlobStore{Root: root}, nil
+}
+
+// Put writes the blob to a temporary file first so readers never see a partial file.
+func (th *LocalBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := th.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (th *LocalBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := th.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return file, err
+}
+
+// GetRange returns length bytes of the blob starting at offset.
+func (th *LocalBlobStore) GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	content, err := th.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	file := content.(*os.File)
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, length), file}, nil
+}
+
+func (th *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := th.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path maps a key into the root directory, rejecting keys that would escape it.
+func (th *LocalBlobStore) path(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(th.Root, cleaned), nil
+}
@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"social-connector/internal/config"
+	"strings"
+	"time"
+)
+
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3BlobStore keeps blobs in a bucket of any S3-compatible service (AWS S3, MinIO, R2...).
+// Requests are signed with AWS Signature Version 4.
+type S3BlobStore struct {
+	HttpClient      *http.Client
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UsePathStyle    bool
+}
+
+func NewS3BlobStore(httpClient *http.Client, config config.S3Config) *S3BlobStore {
+	return &S3BlobStore{
+		HttpClient:      httpClient,
+		Endpoint:        strings.TrimRight(config.Endpoint, "/"),
+		Region:          config.Region,
+		Bucket:          config.Bucket,
+		AccessKeyID:     config.AccessKeyID,
+		SecretAccessKey: config.SecretAccessKey,
+		UsePathStyle:    config.UsePathStyle,
+	}
+}
+
+func (th *S3BlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	headers := http.Header{}
+	if contentType != "" {
+		headers.Set("Content-Type", contentType)
+	}
+
+	res, err := th.do(ctx, http.MethodPut, key, data, headers)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to upload %s: unexpected HTTP status %d response_body %s", key, res.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (th *S3BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return th.get(ctx, key, http.Header{})
+}
+
+// GetRange returns length bytes of the blob starting at offset using an HTTP range request.
+func (th *S3BlobStore) GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	headers := http.Header{}
+	headers.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	return th.get(ctx, key, headers)
+}
+
+func (th *S3BlobStore) get(ctx context.Context, key string, headers http.Header) (io.ReadCloser, error) {
+	res, err := th.do(ctx, http.MethodGet, key, nil, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return res.Body, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, ErrBlobNotFound
+	default:
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: unexpected HTTP status %d response_body %s", key, res.StatusCode, string(body))
+	}
+}
+
+func (th *S3BlobStore) Delete(ctx context.Context, key string) error {
+	res, err := th.do(ctx, http.MethodDelete, key, nil, http.Header{})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete %s: unexpected HTTP status %d", key, res.StatusCode)
+	}
+	return nil
+}
+
+func (th *S3BlobStore) do(ctx context.Context, method string, key string, data []byte, headers http.Header) (*http.Response, error) {
+	objectURL, err := th.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	req.ContentLength = int64(len(data))
+
+	th.sign(req, data, time.Now().UTC())
+
+	res, err := th.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	return res, nil
+}
+
+func (th *S3BlobStore) objectURL(key string) (*url.URL, error) {
+	endpoint, err := url.Parse(th.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", th.Endpoint)
+	}
+
+	path := "/" + awsEscapePath(key)
+	if th.UsePathStyle {
+		path = "/" + th.Bucket + path
+	} else {
+		endpoint.Host = th.Bucket + "." + endpoint.Host
+	}
+	endpoint.Path = path
+	endpoint.RawPath = path
+	return endpoint, nil
+}
+
+// sign adds the AWS Signature Version 4 headers to req.
+func (th *S3BlobStore) sign(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	payloadHash := emptyPayloadHash
+	if len(payload) > 0 {
+		sum := sha256.Sum256(payload)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", req.URL.Host, payloadHash, amzDate)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, th.Region)
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(canonicalHash[:])}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+th.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, th.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", th.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// awsEscapePath encodes every segment of a key as required by Signature Version 4:
+// only unreserved characters are left as they are.
+func awsEscapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		var builder strings.Builder
+		for _, b := range []byte(segment) {
+			if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || b == '-' || b == '.' || b == '_' || b == '~' {
+				builder.WriteByte(b)
+			} else {
+				fmt.Fprintf(&builder, "%%%02X", b)
+			}
+		}
+		segments[i] = builder.String()
+	}
+	return strings.Join(segments, "/")
+}
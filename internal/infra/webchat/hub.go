@@ -0,0 +1,107 @@
+package webchat
+
+import (
+	"social-connector/internal/domain/dto"
+	"sync"
+	"time"
+)
+
+const (
+	subscriberBuffer = 32
+	maxPending       = 50
+	pendingTTL       = 10 * time.Minute
+)
+
+type pendingEvent struct {
+	event    dto.WebChatEvent
+	queuedAt time.Time
+}
+
+// Hub routes agent replies to the WebSocket and SSE connections of a web chat session.
+// Replies to a session without an open connection are kept for a few minutes and delivered
+// when the visitor reconnects.
+//
+// The hub lives in memory: with several instances the visitor has to be connected to the
+// instance that processes its messages.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan dto.WebChatEvent]struct{}
+	pending     map[string][]pendingEvent
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: map[string]map[chan dto.WebChatEvent]struct{}{},
+		pending:     map[string][]pendingEvent{},
+	}
+}
+
+// Subscribe opens a stream of the events of a session, starting with the replies queued while
+// the visitor was away. The returned function closes the stream.
+func (th *Hub) Subscribe(sessionID string) (<-chan dto.WebChatEvent, func()) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	events := make(chan dto.WebChatEvent, subscriberBuffer+maxPending)
+	for _, pending := range th.pending[sessionID] {
+		if time.Since(pending.queuedAt) < pendingTTL {
+			events <- pending.event
+		}
+	}
+	delete(th.pending, sessionID)
+
+	if th.subscribers[sessionID] == nil {
+		th.subscribers[sessionID] = map[chan dto.WebChatEvent]struct{}{}
+	}
+	th.subscribers[sessionID][events] = struct{}{}
+
+	return events, func() {
+		th.mu.Lock()
+		defer th.mu.Unlock()
+
+		if _, ok := th.subscribers[sessionID][events]; !ok {
+			return
+		}
+		delete(th.subscribers[sessionID], events)
+		if len(th.subscribers[sessionID]) == 0 {
+			delete(th.subscribers, sessionID)
+		}
+		close(events)
+	}
+}
+
+// Publish sends an event to every open connection of the session and reports whether any
+// connection received it. Slow connections whose buffer is full miss the event.
+func (th *Hub) Publish(sessionID string, event dto.WebChatEvent) bool {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	delivered := false
+	for events := range th.subscribers[sessionID] {
+		select {
+		case events <- event:
+			delivered = true
+		default:
+		}
+	}
+	if delivered {
+		return true
+	}
+
+	th.prune()
+	queue := append(th.pending[sessionID], pendingEvent{event: event, queuedAt: time.Now()})
+	if len(queue) > maxPending {
+		queue = queue[len(queue)-maxPending:]
+	}
+	th.pending[sessionID] = queue
+	return false
+}
+
+// prune drops the queues of sessions that did not come back within pendingTTL.
+func (th *Hub) prune() {
+	for sessionID, queue := range th.pending {
+		if time.Since(queue[len(queue)-1].queuedAt) >= pendingTTL {
+			delete(th.pending, sessionID)
+		}
+	}
+}
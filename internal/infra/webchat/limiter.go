@@ -0,0 +1,56 @@
+package webchat
+
+import (
+	"sync"
+	"time"
+)
+
+type limiterWindow struct {
+	start time.Time
+	count int
+}
+
+// Limiter counts hits per key, a session ID or a client IP, in fixed time windows.
+//
+// Like the hub it lives in memory: with several instances each one enforces the limits on its own.
+type Limiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	windows   map[string]*limiterWindow
+	lastSweep time.Time
+}
+
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:     limit,
+		window:    window,
+		windows:   map[string]*limiterWindow{},
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow records a hit for key and reports whether the key is still within the limit of the current window.
+func (th *Limiter) Allow(key string) bool {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(th.lastSweep) >= th.window {
+		for k, w := range th.windows {
+			if now.Sub(w.start) >= th.window {
+				delete(th.windows, k)
+			}
+		}
+		th.lastSweep = now
+	}
+
+	w, ok := th.windows[key]
+	if !ok || now.Sub(w.start) >= th.window {
+		w = &limiterWindow{start: now}
+		th.windows[key] = w
+	}
+
+	w.count++
+	return w.count <= th.limit
+}
@@ -0,0 +1,8 @@
+package webchat
+
+import _ "embed"
+
+// WidgetScript is the embeddable chat widget served at /webchat/widget.js.
+//
+//go:embed widget.js
+var WidgetScript []byte
@@ -0,0 +1,297 @@
+// Social Connector web chat widget.
+//
+// <script src="https://connector.example.com/webchat/widget.js" data-title="Atendimento" async></script>
+//
+// The widget talks to the connector the script was loaded from. It keeps the visitor session
+// in localStorage, uses a WebSocket and falls back to server-sent events plus POST requests.
+(function () {
+  "use strict";
+
+  var script = document.currentScript;
+  if (!script || window.__socialConnectorWebChat) {
+    return;
+  }
+  window.__socialConnectorWebChat = true;
+
+  var baseURL = new URL(script.src).origin;
+  var title = script.getAttribute("data-title") || "Chat";
+  var placeholder = script.getAttribute("data-placeholder") || "Digite sua mensagem...";
+  var storageKey = "social-connector-webchat:" + baseURL;
+
+  var session = null;
+  var socket = null;
+  var stream = null;
+  var seen = {};
+
+  // ---- UI ---------------------------------------------------------------------------------
+
+  var style = document.createElement("style");
+  style.textContent = [
+    ".scw-button{position:fixed;right:20px;bottom:20px;width:56px;height:56px;border-radius:50%;border:0;background:#128c7e;color:#fff;font-size:26px;cursor:pointer;box-shadow:0 4px 12px rgba(0,0,0,.25);z-index:2147483000}",
+    ".scw-panel{position:fixed;right:20px;bottom:88px;width:340px;max-width:calc(100vw - 40px);height:480px;max-height:calc(100vh - 120px);display:none;flex-direction:column;background:#fff;border-radius:12px;box-shadow:0 8px 24px rgba(0,0,0,.25);overflow:hidden;font:14px/1.4 system-ui,sans-serif;z-index:2147483000}",
+    ".scw-panel.scw-open{display:flex}",
+    ".scw-header{padding:12px 16px;background:#128c7e;color:#fff;font-weight:600}",
+    ".scw-messages{flex:1;overflow-y:auto;padding:12px;background:#f4f4f4}",
+    ".scw-message{max-width:80%;margin:4px 0;padding:8px 10px;border-radius:8px;white-space:pre-wrap;word-wrap:break-word}",
+    ".scw-user{margin-left:auto;background:#dcf8c6}",
+    ".scw-agent{margin-right:auto;background:#fff}",
+    ".scw-message img,.scw-message video,.scw-message audio{max-width:100%;display:block}",
+    ".scw-options{display:flex;flex-wrap:wrap;gap:4px;margin-top:6px}",
+    ".scw-option{border:1px solid #128c7e;background:#fff;color:#128c7e;border-radius:14px;padding:4px 10px;cursor:pointer}",
+    ".scw-form{display:flex;border-top:1px solid #ddd}",
+    ".scw-input{flex:1;border:0;padding:12px;font:inherit;outline:none}",
+    ".scw-send{border:0;background:none;color:#128c7e;font-weight:600;padding:0 16px;cursor:pointer}"
+  ].join("\n");
+  document.head.appendChild(style);
+
+  var button = element("button", "scw-button", "💬");
+  button.setAttribute("aria-label", title);
+  var panel = element("div", "scw-panel");
+  var header = element("div", "scw-header", title);
+  var messages = element("div", "scw-messages");
+  var form = element("form", "scw-form");
+  var input = element("input", "scw-input");
+  input.placeholder = placeholder;
+  input.maxLength = 2000;
+  var send = element("button", "scw-send", "Enviar");
+  send.type = "submit";
+
+  form.appendChild(input);
+  form.appendChild(send);
+  panel.appendChild(header);
+  panel.appendChild(messages);
+  panel.appendChild(form);
+  document.body.appendChild(panel);
+  document.body.appendChild(button);
+
+  button.addEventListener("click", function () {
+    panel.classList.toggle("scw-open");
+    if (panel.classList.contains("scw-open")) {
+      start();
+      input.focus();
+    }
+  });
+
+  form.addEventListener("submit", function (event) {
+    event.preventDefault();
+    var text = input.value.trim();
+    if (text) {
+      input.value = "";
+      submit({ text: text });
+    }
+  });
+
+  function element(tag, className, text) {
+    var node = document.createElement(tag);
+    if (className) {
+      node.className = className;
+    }
+    if (text) {
+      node.textContent = text;
+    }
+    return node;
+  }
+
+  function append(role, node) {
+    node.classList.add("scw-message", role === "user" ? "scw-user" : "scw-agent");
+    messages.appendChild(node);
+    messages.scrollTop = messages.scrollHeight;
+  }
+
+  function appendText(role, text) {
+    append(role, element("div", null, text));
+  }
+
+  function options(node, body, choices) {
+    node.appendChild(element("div", null, body));
+    var list = element("div", "scw-options");
+    choices.forEach(function (choice) {
+      var option = element("button", "scw-option", choice.title);
+      option.type = "button";
+      option.addEventListener("click", function () {
+        submit({ text: choice.title, reply_id: choice.id });
+      });
+      list.appendChild(option);
+    });
+    node.appendChild(list);
+  }
+
+  // render shows an agent dto.ChannelMessage.
+  function render(message) {
+    var node = element("div");
+    switch (message.type) {
+      case "audio":
+        var audio = element("audio");
+        audio.controls = true;
+        audio.src = message.media.link;
+        node.appendChild(audio);
+        break;
+      case "media":
+        var media = message.media;
+        if (media.type === "image" || media.type === "sticker") {
+          var image = element("img");
+          image.src = media.link;
+          image.alt = media.caption || "";
+          node.appendChild(image);
+        } else if (media.type === "video") {
+          var video = element("video");
+          video.controls = true;
+          video.src = media.link;
+          node.appendChild(video);
+        } else {
+          var link = element("a", null, media.filename || media.link);
+          link.href = media.link;
+          link.target = "_blank";
+          link.rel = "noopener";
+          node.appendChild(link);
+        }
+        if (media.caption) {
+          node.appendChild(element("div", null, media.caption));
+        }
+        break;
+      case "buttons":
+        options(node, message.buttons.body, message.buttons.buttons);
+        break;
+      case "list":
+        var rows = [];
+        message.list.sections.forEach(function (section) {
+          rows = rows.concat(section.rows);
+        });
+        options(node, message.list.body, rows);
+        break;
+      default:
+        node.textContent = message.text;
+    }
+    append("agent", node);
+  }
+
+  // ---- Connector API ----------------------------------------------------------------------
+
+  function request(method, path, body) {
+    var headers = { "Content-Type": "application/json" };
+    if (session) {
+      headers.Authorization = "Bearer " + session.token;
+    }
+    return fetch(baseURL + path, {
+      method: method,
+      headers: headers,
+      body: body ? JSON.stringify(body) : undefined
+    }).then(function (response) {
+      if (!response.ok) {
+        var error = new Error("HTTP " + response.status);
+        error.status = response.status;
+        throw error;
+      }
+      return response.status === 202 ? null : response.json();
+    });
+  }
+
+  function loadSession() {
+    try {
+      var stored = JSON.parse(localStorage.getItem(storageKey));
+      if (stored && new Date(stored.expires_at) > new Date()) {
+        return Promise.resolve(stored);
+      }
+    } catch (e) {}
+
+    return request("POST", "/webchat/sessions").then(function (created) {
+      localStorage.setItem(storageKey, JSON.stringify(created));
+      return created;
+    });
+  }
+
+  function start() {
+    if (session) {
+      return;
+    }
+    loadSession()
+      .then(function (loaded) {
+        session = loaded;
+        return request("GET", "/webchat/history");
+      })
+      .then(function (transcript) {
+        (transcript || []).forEach(function (entry) {
+          appendText(entry.role === "user" ? "user" : "agent", entry.message);
+        });
+        connect();
+      })
+      .catch(function (error) {
+        if (error.status === 401) {
+          localStorage.removeItem(storageKey);
+          session = null;
+        }
+        appendText("agent", "Não foi possível conectar ao chat.");
+      });
+  }
+
+  function receive(event) {
+    if (event.id && seen[event.id]) {
+      return;
+    }
+    seen[event.id] = true;
+    if (event.type === "message") {
+      render(event.message);
+    } else if (event.type === "error") {
+      appendText("agent", event.error);
+    }
+  }
+
+  function connect() {
+    var token = encodeURIComponent(session.token);
+    if (!("WebSocket" in window)) {
+      return listen();
+    }
+
+    var opened = false;
+    socket = new WebSocket(baseURL.replace(/^http/, "ws") + "/webchat/ws?token=" + token);
+    socket.onopen = function () {
+      opened = true;
+    };
+    socket.onmessage = function (message) {
+      receive(JSON.parse(message.data));
+    };
+    socket.onclose = function () {
+      socket = null;
+      if (opened) {
+        setTimeout(connect, 3000);
+      } else {
+        listen();
+      }
+    };
+  }
+
+  // listen is the fallback when the WebSocket cannot be opened.
+  function listen() {
+    if (stream) {
+      return;
+    }
+    stream = new EventSource(baseURL + "/webchat/events?token=" + encodeURIComponent(session.token));
+    ["message", "error"].forEach(function (type) {
+      stream.addEventListener(type, function (message) {
+        if (message.data) {
+          receive(JSON.parse(message.data));
+        }
+      });
+    });
+  }
+
+  function submit(message) {
+    if (!session) {
+      return;
+    }
+    message.id = Date.now().toString(36) + Math.random().toString(36).slice(2, 8);
+    appendText("user", message.text);
+
+    if (socket && socket.readyState === WebSocket.OPEN) {
+      socket.send(JSON.stringify(message));
+      return;
+    }
+    request("POST", "/webchat/messages", message).catch(function (error) {
+      if (error.status === 429) {
+        appendText("agent", "Muitas mensagens em pouco tempo, aguarde um momento.");
+        return;
+      }
+      appendText("agent", "Não foi possível enviar a mensagem.");
+    });
+  }
+})();
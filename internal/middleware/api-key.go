@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"social-connector/internal/infra/logger"
+	"strings"
+)
+
+// APIKeyMiddleware protects the connector's own API. Callers must send the key in the
+// X-Api-Key header or as a Bearer token. With no key configured every request is rejected.
+func APIKeyMiddleware(log *logger.Logger, apiKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := r.Header.Get("X-Api-Key")
+			if provided == "" {
+				provided = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			}
+
+			if apiKey == "" || provided == "" || !secureEqual(provided, apiKey) {
+				log.Warn(fmt.Sprintf("Rejected API request %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr))
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
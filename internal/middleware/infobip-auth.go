@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"social-connector/internal/config"
+	"social-connector/internal/infra/logger"
+	"strings"
+	"sync/atomic"
+)
+
+// InfobipAuthMiddleware authenticates Infobip inbound webhook deliveries.
+//
+// A request is accepted when its source IP is in the allowlist (if one is configured) and it
+// presents at least one of the configured credentials: Basic auth, a shared-secret header or
+// a query token. With no credential configured every request is rejected.
+type InfobipAuthMiddleware struct {
+	Logger *logger.Logger
+	Config config.InfobipWebhookConfig
+
+	allowedNetworks []*net.IPNet
+	failures        atomic.Int64
+}
+
+func NewInfobipAuthMiddleware(logger *logger.Logger, config config.InfobipWebhookConfig) (*InfobipAuthMiddleware, error) {
+	m := &InfobipAuthMiddleware{Logger: logger, Config: config}
+
+	for _, cidr := range config.AllowedCIDRs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			if strings.Contains(cidr, ":") {
+				cidr += "/128"
+			} else {
+				cidr += "/32"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Infobip allowlist entry %s: %w", cidr, err)
+		}
+		m.allowedNetworks = append(m.allowedNetworks, network)
+	}
+
+	if !m.hasCredentials() {
+		logger.Error("No Infobip webhook credentials configured, every Infobip webhook request will be rejected")
+	}
+
+	return m, nil
+}
+
+// Middleware implements mux.MiddlewareFunc.
+func (m *InfobipAuthMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reason := m.verify(r); reason != "" {
+			total := m.failures.Add(1)
+			m.Logger.Warn(fmt.Sprintf("Rejected Infobip webhook request from %s: %s, total failures %d", m.sourceIP(r), reason, total))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// FailureCount returns how many requests failed verification since startup.
+func (m *InfobipAuthMiddleware) FailureCount() int64 {
+	return m.failures.Load()
+}
+
+// verify returns the reason a request failed verification, or an empty string if it passed.
+func (m *InfobipAuthMiddleware) verify(r *http.Request) string {
+	if len(m.allowedNetworks) > 0 && !m.allowedIP(m.sourceIP(r)) {
+		return "source IP not allowed"
+	}
+
+	if !m.hasCredentials() {
+		return "no credentials configured"
+	}
+
+	if m.Config.BasicUsername != "" {
+		username, password, ok := r.BasicAuth()
+		if ok && secureEqual(username, m.Config.BasicUsername) && secureEqual(password, m.Config.BasicPassword) {
+			return ""
+		}
+	}
+
+	if m.Config.HeaderSecret != "" {
+		if value := r.Header.Get(m.Config.HeaderName); value != "" && secureEqual(value, m.Config.HeaderSecret) {
+			return ""
+		}
+	}
+
+	if m.Config.QueryToken != "" {
+		if value := r.URL.Query().Get(m.Config.QueryParam); value != "" && secureEqual(value, m.Config.QueryToken) {
+			return ""
+		}
+	}
+
+	return "missing or invalid credentials"
+}
+
+func (m *InfobipAuthMiddleware) hasCredentials() bool {
+	return m.Config.BasicUsername != "" || m.Config.HeaderSecret != "" || m.Config.QueryToken != ""
+}
+
+func (m *InfobipAuthMiddleware) allowedIP(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, network := range m.allowedNetworks {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *InfobipAuthMiddleware) sourceIP(r *http.Request) string {
+	return ClientIP(r, m.Config.TrustForwardedFor)
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
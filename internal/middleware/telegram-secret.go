@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"social-connector/internal/infra/logger"
+)
+
+const telegramSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// TelegramSecretMiddleware authenticates Telegram webhook deliveries. Telegram sends the
+// secret_token registered with setWebhook in the X-Telegram-Bot-Api-Secret-Token header;
+// requests without it are rejected with 401. With no secret configured every request is rejected.
+func TelegramSecretMiddleware(log *logger.Logger, secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := r.Header.Get(telegramSecretHeader)
+			if secret == "" || provided == "" || !secureEqual(provided, secret) {
+				log.Warn(fmt.Sprintf("Rejected Telegram webhook request with missing or invalid secret token from %s", r.RemoteAddr))
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Package phone parses international phone numbers into E.164 and formats them for the
+// WhatsApp providers.
+package phone
+
+import (
+	"fmt"
+	"strings"
+)
+
+// E.164 limits the full number, country code included, to 15 digits.
+const (
+	minDigits = 8
+	maxDigits = 15
+)
+
+const BRAZIL_COUNTRY_CODE = "55"
+
+// Number is a phone number split into its country calling code and national number, both as digits only.
+type Number struct {
+	CountryCode string
+	National    string
+}
+
+// nationalLength is the accepted national number length for the countries with a known numbering plan.
+// Other countries are only checked against the E.164 limits.
+var nationalLength = map[string][2]int{
+	"1":   {10, 10}, // NANP
+	"34":  {9, 9},   // Spain
+	"44":  {9, 10},  // United Kingdom
+	"52":  {10, 10}, // Mexico
+	"54":  {10, 11}, // Argentina (mobile numbers carry an extra 9)
+	"55":  {10, 11}, // Brazil
+	"56":  {9, 9},   // Chile
+	"57":  {10, 10}, // Colombia
+	"351": {9, 9},   // Portugal
+	"598": {8, 8},   // Uruguay
+}
+
+// countryCodes lists the assigned ITU-T E.164 country calling codes. They form a prefix code,
+// so at most one of them matches the start of a number.
+var countryCodes = map[string]bool{}
+
+func init() {
+	codes := "1 7 20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49 51 52 53 54 55 56 57 58 60 61 62 63 64 65 66 81 82 84 86 90 91 92 93 94 95 98 " +
+		"211 212 213 216 218 220 221 222 223 224 225 226 227 228 229 230 231 232 233 234 235 236 237 238 239 240 241 242 243 244 245 246 247 248 249 " +
+		"250 251 252 253 254 255 256 257 258 260 261 262 263 264 265 266 267 268 269 290 291 297 298 299 " +
+		"350 351 352 353 354 355 356 357 358 359 370 371 372 373 374 375 376 377 378 380 381 382 383 385 386 387 389 420 421 423 " +
+		"500 501 502 503 504 505 506 507 508 509 590 591 592 593 594 595 596 597 598 599 " +
+		"670 672 673 674 675 676 677 678 679 680 681 682 683 685 686 687 688 689 690 691 692 " +
+		"850 852 853 855 856 880 886 960 961 962 963 964 965 966 967 968 970 971 972 973 974 975 976 977 992 993 994 995 996 998"
+	for _, code := range strings.Fields(codes) {
+		countryCodes[code] = true
+	}
+}
+
+// Parse reads an international number, with or without a leading "+" or "00", ignoring spaces,
+// dashes, dots and parentheses. WhatsApp IDs are international numbers without the "+".
+//
+// Returns:
+//   - Number: The number split into country code and national number.
+//   - error: Returns an error if the number has other characters, an unknown country code or a
+//     length not valid for E.164 or for its country.
+func Parse(raw string) (Number, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+
+	digits = strings.TrimPrefix(digits, "+")
+	if strings.HasPrefix(digits, "00") {
+		digits = digits[2:]
+	}
+
+	if digits == "" {
+		return Number{}, fmt.Errorf("phone number is empty")
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return Number{}, fmt.Errorf("phone number %q has invalid characters", raw)
+		}
+	}
+	if len(digits) < minDigits || len(digits) > maxDigits {
+		return Number{}, fmt.Errorf("phone number %q must have %d to %d digits", raw, minDigits, maxDigits)
+	}
+
+	for size := 1; size <= 3; size++ {
+		code := digits[:size]
+		if !countryCodes[code] {
+			continue
+		}
+
+		number := Number{CountryCode: code, National: digits[size:]}
+		if err := number.validate(); err != nil {
+			return Number{}, fmt.Errorf("phone number %q: %w", raw, err)
+		}
+		return number, nil
+	}
+
+	return Number{}, fmt.Errorf("phone number %q has an unknown country code", raw)
+}
+
+func (n Number) validate() error {
+	if limits, ok := nationalLength[n.CountryCode]; ok {
+		if len(n.National) < limits[0] || len(n.National) > limits[1] {
+			return fmt.Errorf("national number of country %s must have %d to %d digits", n.CountryCode, limits[0], limits[1])
+		}
+	}
+
+	if n.CountryCode == BRAZIL_COUNTRY_CODE {
+		if n.National[0] == '0' || n.National[1] == '0' {
+			return fmt.Errorf("invalid Brazilian area code %s", n.National[:2])
+		}
+		if len(n.National) == 11 && n.National[2] != '9' {
+			return fmt.Errorf("Brazilian 9-digit numbers must be mobile numbers starting with 9")
+		}
+	}
+
+	return nil
+}
+
+// E164 returns the number in E.164 format, e.g. +5511987654321.
+func (n Number) E164() string {
+	return "+" + n.CountryCode + n.National
+}
+
+// Digits returns the number in international format without the "+".
+func (n Number) Digits() string {
+	return n.CountryCode + n.National
+}
+
+// IsBrazilianLegacyMobile reports whether the number is a Brazilian mobile number still written
+// without the ninth digit: an 8-digit subscriber number in the mobile ranges (6 to 9).
+func (n Number) IsBrazilianLegacyMobile() bool {
+	if n.CountryCode != BRAZIL_COUNTRY_CODE || len(n.National) != 10 {
+		return false
+	}
+	first := n.National[2]
+	return first >= '6' && first <= '9'
+}
+
+// WithNinthDigit returns the number with the Brazilian ninth digit added when the rule applies.
+// Every other number, including Brazilian landlines, is returned unchanged.
+func (n Number) WithNinthDigit() Number {
+	if !n.IsBrazilianLegacyMobile() {
+		return n
+	}
+	return Number{CountryCode: n.CountryCode, National: n.National[:2] + "9" + n.National[2:]}
+}
+
+// Meta returns the recipient format for the Meta Cloud API: international digits without the
+// "+", with the ninth digit the WhatsApp ID of older Brazilian accounts omits.
+func (n Number) Meta() string {
+	return n.WithNinthDigit().Digits()
+}
+
+// Infobip returns the recipient format for the Infobip API: international digits without the "+".
+func (n Number) Infobip() string {
+	return n.Digits()
+}
@@ -0,0 +1,115 @@
+package phone
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    Number
+		wantErr bool
+	}{
+		{name: "plus prefix", raw: "+5511987654321", want: Number{CountryCode: "55", National: "11987654321"}},
+		{name: "double zero prefix", raw: "005511987654321", want: Number{CountryCode: "55", National: "11987654321"}},
+		{name: "no prefix", raw: "5511987654321", want: Number{CountryCode: "55", National: "11987654321"}},
+		{name: "separators", raw: " +55 (11) 98765-4321 ", want: Number{CountryCode: "55", National: "11987654321"}},
+		{name: "dots", raw: "+1.415.555.2671", want: Number{CountryCode: "1", National: "4155552671"}},
+		{name: "three digit country code", raw: "+351912345678", want: Number{CountryCode: "351", National: "912345678"}},
+		{name: "country without numbering plan", raw: "+4915123456789", want: Number{CountryCode: "49", National: "15123456789"}},
+		{name: "minimum length", raw: "+59891234567", want: Number{CountryCode: "598", National: "91234567"}},
+		{name: "maximum length", raw: "+491512345678901", want: Number{CountryCode: "49", National: "1512345678901"}},
+		{name: "empty", raw: "", wantErr: true},
+		{name: "only prefix", raw: "+", wantErr: true},
+		{name: "one character", raw: "5", wantErr: true},
+		{name: "three characters", raw: "+55", wantErr: true},
+		{name: "below minimum length", raw: "+5511234", wantErr: true},
+		{name: "above maximum length", raw: "+4915123456789012", wantErr: true},
+		{name: "letters", raw: "+55 11 9876-ABCD", wantErr: true},
+		{name: "unknown country code", raw: "+8001234567", wantErr: true},
+		{name: "national number too short", raw: "+55119876543", wantErr: true},
+		{name: "national number too long", raw: "+551198765432100", wantErr: true},
+		{name: "brazilian area code with zero", raw: "+5501987654321", wantErr: true},
+		{name: "brazilian 9-digit landline", raw: "+5511387654321", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tt.raw, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithNinthDigit(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		legacy bool
+		want   string
+	}{
+		{name: "legacy mobile gets the ninth digit", raw: "+551187654321", legacy: true, want: "5511987654321"},
+		{name: "legacy mobile in the 6 range", raw: "+552167654321", legacy: true, want: "5521967654321"},
+		{name: "landline is unchanged", raw: "+551133334444", legacy: false, want: "551133334444"},
+		{name: "mobile with ninth digit is unchanged", raw: "+5511987654321", legacy: false, want: "5511987654321"},
+		{name: "other countries are unchanged", raw: "+14155552671", legacy: false, want: "14155552671"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.raw, err)
+			}
+			if got := number.IsBrazilianLegacyMobile(); got != tt.legacy {
+				t.Errorf("IsBrazilianLegacyMobile() = %v, want %v", got, tt.legacy)
+			}
+			if got := number.WithNinthDigit().Digits(); got != tt.want {
+				t.Errorf("WithNinthDigit() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		e164    string
+		meta    string
+		infobip string
+	}{
+		{name: "brazilian legacy mobile", raw: "551187654321", e164: "+551187654321", meta: "5511987654321", infobip: "551187654321"},
+		{name: "brazilian mobile", raw: "+55 11 98765-4321", e164: "+5511987654321", meta: "5511987654321", infobip: "5511987654321"},
+		{name: "brazilian landline", raw: "+55 11 3333-4444", e164: "+551133334444", meta: "551133334444", infobip: "551133334444"},
+		{name: "portugal", raw: "00351 912 345 678", e164: "+351912345678", meta: "351912345678", infobip: "351912345678"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.raw, err)
+			}
+			if got := number.E164(); got != tt.e164 {
+				t.Errorf("E164() = %s, want %s", got, tt.e164)
+			}
+			if got := number.Meta(); got != tt.meta {
+				t.Errorf("Meta() = %s, want %s", got, tt.meta)
+			}
+			if got := number.Infobip(); got != tt.infobip {
+				t.Errorf("Infobip() = %s, want %s", got, tt.infobip)
+			}
+		})
+	}
+}
@@ -4,18 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	stdlog "log"
 	"net/http"
 	"os"
 	"os/signal"
 	"social-connector/internal/config"
 	"social-connector/internal/domain/entities"
 	Iservices "social-connector/internal/domain/interfaces/services"
+	"social-connector/internal/infra/channel"
+	"social-connector/internal/infra/events"
 	"social-connector/internal/infra/handlers"
 	"social-connector/internal/infra/logger"
 	"social-connector/internal/infra/provider"
 	"social-connector/internal/infra/repository"
 	"social-connector/internal/infra/routes"
 	"social-connector/internal/infra/services"
+	"social-connector/internal/infra/storage"
+	"social-connector/internal/infra/webchat"
 	"social-connector/internal/middleware"
 	client "social-connector/internal/pkg"
 	"time"
@@ -24,13 +29,16 @@ import (
 )
 
 func main() {
-	config.LoadEnv()
+	cfg, err := config.Load()
+	if err != nil {
+		stdlog.Fatalf("Invalid configuration:\n%v", err)
+	}
 
 	ctx := context.Background()
-	log := logger.NewLogger(ctx, true)
+	log := logger.NewLogger(ctx, true, cfg.Server.LogLevel)
 
-	mongoClient := client.MongoClient()
-	userContextDB := mongoClient.Database("UserContext")
+	mongoClient := client.MongoClient(cfg.Mongo.URI)
+	userContextDB := mongoClient.Database(cfg.Mongo.Database)
 
 	router := mux.NewRouter()
 	router.Use(middleware.LoggingMiddleware(log))
@@ -39,28 +47,140 @@ func main() {
 
 	userContextRepo := repository.NewMongoRepository[entities.UserContext](userContextDB)
 
-	var whatsAppProvider provider.IWhatsAppProvider = provider.NewInfobipWhatsAppProvider(log, &httpClient)
+	infobipTokenManager := provider.NewInfobipTokenManager(log, &httpClient, cfg.Infobip)
+	infobipProvider := provider.NewInfobipWhatsAppProvider(log, &httpClient, cfg.Infobip, infobipTokenManager)
+	metaWhatsAppProvider := provider.NewMetaWhatsAppProvider(log, &httpClient, cfg.Meta, repository.NewMongoMediaUploadRepository(userContextDB))
+	if err := metaWhatsAppProvider.Start(ctx); err != nil {
+		log.Fatal(fmt.Sprintf("Failed to start Meta media upload cache: %v", err))
+	}
+	channels := channel.NewRegistry(
+		channel.NewInfobipWhatsAppChannel(infobipProvider),
+		channel.NewMetaWhatsAppChannel(metaWhatsAppProvider),
+	)
+	telegramProvider := provider.NewTelegramProvider(log, &httpClient, cfg.Telegram)
+	if cfg.TelegramEnabled() {
+		channels.Register(channel.NewTelegramChannel(telegramProvider))
+	}
+	if cfg.MessengerEnabled() {
+		channels.Register(channel.NewMessengerChannel(provider.NewMessengerProvider(log, &httpClient, cfg.Meta)))
+	}
+	if cfg.InstagramEnabled() {
+		channels.Register(channel.NewMessengerChannel(provider.NewInstagramProvider(log, &httpClient, cfg.Meta)))
+	}
+	webChatHub := webchat.NewHub()
+	if cfg.WebChat.Enabled {
+		channels.Register(channel.NewWebChatChannel(webChatHub))
+	}
+
+	eventBus := events.NewBus(log)
+	eventBus.Subscribe(events.MESSAGE_FAILED, func(payload interface{}) {
+		if message, ok := payload.(entities.OutboundMessage); ok {
+			log.Error(fmt.Sprintf("Outbound message %s to conversation %s failed: %+v", message.ProviderMessageID, message.ConversationID, message.Errors))
+		}
+	})
+
+	outboundMessageRepo := repository.NewMongoOutboundMessageRepository(userContextDB)
+	messageStatusService := services.NewMessageStatusService(outboundMessageRepo, eventBus, ctx, log)
+	if err := messageStatusService.Start(ctx); err != nil {
+		log.Fatal(fmt.Sprintf("Failed to start message status store: %v", err))
+	}
 
 	var userContextSvc Iservices.IUserContextService = services.NewUserContextService(userContextRepo, ctx, log)
-	var queryAIService Iservices.IQueryAIService = services.NewQueryAIService(log)
-	var channelService Iservices.IChannelServices = services.NewChannelService(log, userContextSvc, queryAIService, whatsAppProvider)
+	var queryAIService Iservices.IQueryAIService = services.NewQueryAIService(log, cfg.QueryAI)
+	blobStore, err := storage.NewBlobStore(&httpClient, cfg.Media)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("Failed to create media store: %v", err))
+	}
+	mediaRepo := repository.NewMongoMediaRepository(userContextDB)
+	mediaService := services.NewMediaService(mediaRepo, blobStore, channels, &httpClient, ctx, log, cfg.Media)
+	if err := mediaService.Start(ctx); err != nil {
+		log.Fatal(fmt.Sprintf("Failed to start media store: %v", err))
+	}
+
+	var channelService Iservices.IChannelServices = services.NewChannelService(log, userContextSvc, queryAIService, messageStatusService, mediaService, channels, cfg.Messages, cfg.Media)
+
+	templateService, err := services.NewTemplateService(log, channels, messageStatusService, cfg.Templates)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("Failed to load message templates: %v", err))
+	}
+
+	processedMessageRepo := repository.NewMongoProcessedMessageRepository(userContextDB)
+	deduplicationService := services.NewDeduplicationService(processedMessageRepo, ctx, log, time.Duration(cfg.Queue.DedupTTLHours)*time.Hour)
+	if err := deduplicationService.Start(ctx); err != nil {
+		log.Fatal(fmt.Sprintf("Failed to start deduplication store: %v", err))
+	}
+
+	inboundQueueRepo := repository.NewMongoInboundQueueRepository(userContextDB)
+	inboundQueueService := services.NewInboundQueueService(inboundQueueRepo, channelService, deduplicationService, ctx, log, cfg.Queue)
+
+	workersCtx, stopWorkers := context.WithCancel(ctx)
+	if err := inboundQueueService.Start(workersCtx); err != nil {
+		log.Fatal(fmt.Sprintf("Failed to start inbound queue: %v", err))
+	}
+
+	telegramPollingService := services.NewTelegramPollingService(log, telegramProvider, inboundQueueService, cfg.Telegram)
+	if cfg.TelegramEnabled() {
+		switch {
+		case cfg.Telegram.Mode == "polling":
+			if err := telegramPollingService.Start(workersCtx); err != nil {
+				log.Fatal(fmt.Sprintf("Failed to start Telegram polling: %v", err))
+			}
+		case cfg.Telegram.WebhookURL != "":
+			if err := telegramProvider.SetWebhook(ctx, cfg.Telegram.WebhookURL, cfg.Telegram.WebhookSecret); err != nil {
+				log.Fatal(fmt.Sprintf("Failed to register Telegram webhook: %v", err))
+			}
+		}
+	}
+
+	//Meta whatsApp business, Messenger and Instagram
+	transactionHandlers := handlers.NewHttpHandlers(log, cfg.Meta.VerifyToken, inboundQueueService, messageStatusService)
+
+	infobipHandlers := handlers.NewInfobipHandlers(log, inboundQueueService, messageStatusService)
 
-	verifyToken := config.GetEnv("API_KEY")
+	messageStatusHandlers := handlers.NewMessageStatusHandlers(log, messageStatusService)
 
-	//Meta whatsApp business
-	transactionHandlers := handlers.NewHttpHandlers(log, verifyToken, userContextSvc, queryAIService)
+	templateHandlers := handlers.NewTemplateHandlers(log, templateService)
 
-	infobipHandlers := handlers.NewInfobipHandlers(log, channelService)
+	mediaHandlers := handlers.NewMediaHandlers(log, mediaService)
+
+	channelHandlers := handlers.NewChannelHandlers(log, channels)
+
+	telegramHandlers := handlers.NewTelegramHandlers(log, inboundQueueService)
+
+	infobipAuth, err := middleware.NewInfobipAuthMiddleware(log, cfg.Infobip.Webhook)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("Invalid Infobip webhook authentication settings: %v", err))
+	}
+
+	statsHandlers := handlers.NewStatsHandlers(log, deduplicationService, infobipAuth)
+
+	var webChatHandlers *handlers.WebChatHandlers
+	if cfg.WebChat.Enabled {
+		webChatService := services.NewWebChatService(log, inboundQueueService, userContextSvc, mediaService, webChatHub, cfg.WebChat)
+		webChatHandlers = handlers.NewWebChatHandlers(log, webChatService, cfg.WebChat)
+	}
 
 	routes := routes.NewRoutes(
 		router,
 		transactionHandlers,
 		infobipHandlers,
+		messageStatusHandlers,
+		templateHandlers,
+		mediaHandlers,
+		channelHandlers,
+		telegramHandlers,
+		webChatHandlers,
+		statsHandlers,
+		middleware.MetaSignatureMiddleware(log, cfg.Meta.AppSecret),
+		infobipAuth.Middleware,
+		middleware.TelegramSecretMiddleware(log, cfg.Telegram.WebhookSecret),
+		middleware.CORSMiddleware(cfg.WebChat.AllowedOrigins),
+		middleware.APIKeyMiddleware(log, cfg.Server.AdminAPIKey),
 	)
 
 	routes.Init()
 
-	port := config.GetEnv("PORT")
+	port := cfg.Server.Port
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%s", port),
 		Handler: router,
@@ -87,4 +207,9 @@ func main() {
 	} else {
 		log.Info("Server stopped gracefully.")
 	}
+
+	stopWorkers()
+	telegramPollingService.Wait()
+	inboundQueueService.Wait()
+	log.Info("Inbound queue workers stopped.")
 }